@@ -3,59 +3,105 @@ package main
 import (
 	"context"
 	"crypto/subtle"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/LJTian/TrendingHub/internal/api"
 	"github.com/LJTian/TrendingHub/internal/collector"
 	"github.com/LJTian/TrendingHub/internal/config"
+	"github.com/LJTian/TrendingHub/internal/enricher"
+	"github.com/LJTian/TrendingHub/internal/filter"
 	"github.com/LJTian/TrendingHub/internal/processor"
 	"github.com/LJTian/TrendingHub/internal/scheduler"
 	"github.com/LJTian/TrendingHub/internal/storage"
+	"github.com/LJTian/TrendingHub/internal/story"
+	"github.com/LJTian/TrendingHub/internal/summarizer"
+	"github.com/LJTian/TrendingHub/internal/tagger"
 	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
 )
 
 func main() {
 	cfg := config.Load()
 
+	// 收到 SIGINT / SIGTERM 后 ctx 被取消，进入优雅退出流程
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	store, err := storage.NewStore(cfg.PostgresDSN, cfg.RedisAddr)
 	if err != nil {
 		log.Fatalf("init store failed: %v", err)
 	}
 
-	// 确保各个渠道存在
-	if _, err := store.EnsureChannel("github", "GitHub Trending", "https://github.com/trending"); err != nil {
-		log.Fatalf("ensure channel github failed: %v", err)
-	}
-	if _, err := store.EnsureChannel("baidu", "百度热搜", "https://top.baidu.com/board?tab=realtime"); err != nil {
-		log.Fatalf("ensure channel baidu failed: %v", err)
-	}
-	if _, err := store.EnsureChannel("gold", "金融", ""); err != nil {
-		log.Fatalf("ensure channel gold failed: %v", err)
+	// 首次启动写入默认渠道及其采集配置；之后以数据库为准，可通过 /api/v1/admin/channels 修改
+	if err := store.SeedChannels(defaultChannels); err != nil {
+		log.Fatalf("seed channels failed: %v", err)
 	}
-	if _, err := store.EnsureChannel("hackernews", "Hacker News", "https://news.ycombinator.com"); err != nil {
-		log.Fatalf("ensure channel hackernews failed: %v", err)
-	}
-
 	// 确保默认城市"北京"存在
 	if err := store.AddWeatherCity("北京"); err != nil {
 		log.Printf("warn: ensure default weather city: %v", err)
 	}
 
 	// 启动时在后台预取天气，不阻塞主流程；首次请求若未命中缓存可稍后刷新
-	go refreshWeather(store, cfg.QWeatherAPIKey, cfg.QWeatherAPIHost)
-
-	// 按数据源更新频率配置独立的采集周期；A 股自选股从数据库读取
-	jobs := []scheduler.FetcherJob{
-		{Fetcher: &collector.BaiduHotFetcher{}, CronSpec: "*/30 * * * *"},
-		{Fetcher: &collector.GoldPriceFetcher{}, CronSpec: "*/30 * * * *"},
-		// A 股指数 + 自选股：提高频率到每 3 分钟一次，以获得更平滑的分时折线；
-		// 收盘后仅在“当天尚无任何 A 股数据”时允许再拉一次，用当前价回填当天快照。
-		{
-			Fetcher: &collector.AShareIndexFetcher{
+	var weatherWG sync.WaitGroup
+	weatherWG.Add(1)
+	go func() {
+		defer weatherWG.Done()
+		refreshWeather(ctx, store, cfg.QWeatherAPIKey, cfg.QWeatherAPIHost)
+	}()
+
+	// 翻译：按配置顺序组成回退链，结果按原文哈希缓存在 PostgreSQL / Redis
+	configureTranslation(store, cfg)
+
+	// 采集器类型 → 构造函数；渠道的 fetcherType 必须是这里注册过的类型。A 股自选股从数据库读取
+	factories := map[string]scheduler.FetcherFactory{
+		"baidu_hot":        func(storage.Channel) (collector.Fetcher, error) { return &collector.BaiduHotFetcher{}, nil },
+		"weibo_hot":        func(storage.Channel) (collector.Fetcher, error) { return &collector.WeiboHotFetcher{}, nil },
+		"zhihu_hot":        func(storage.Channel) (collector.Fetcher, error) { return &collector.ZhihuHotFetcher{}, nil },
+		"bilibili_popular": func(storage.Channel) (collector.Fetcher, error) { return &collector.BilibiliPopularFetcher{}, nil },
+		"douyin_hot":       func(storage.Channel) (collector.Fetcher, error) { return &collector.DouyinHotFetcher{}, nil },
+		"gold_price":       func(storage.Channel) (collector.Fetcher, error) { return &collector.GoldPriceFetcher{}, nil },
+		"v2ex_hot":         func(storage.Channel) (collector.Fetcher, error) { return &collector.V2EXHotFetcher{}, nil },
+		"lobsters_hottest": func(storage.Channel) (collector.Fetcher, error) { return &collector.LobstersFetcher{}, nil },
+		"producthunt_daily": func(storage.Channel) (collector.Fetcher, error) {
+			return &collector.ProductHuntFetcher{Token: cfg.ProductHuntToken}, nil
+		},
+		"reddit_hot": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.RedditFetcher{Subreddits: ch.ConfigStrings("subreddits")}, nil
+		},
+		"arxiv": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.ArxivFetcher{Categories: ch.ConfigStrings("categories")}, nil
+		},
+		"papers_trending": func(storage.Channel) (collector.Fetcher, error) { return &collector.TrendingPapersFetcher{}, nil },
+		"hackernews": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.HackerNewsFetcher{
+				Lists:    ch.ConfigStrings("lists"),
+				MaxItems: ch.ConfigInt("perList", 0),
+				Comments: ch.ConfigInt("comments", 0),
+			}, nil
+		},
+		"github_trending": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.GitHubTrendingMock{Languages: ch.ConfigStrings("languages"), Since: ch.ConfigStrings("since")}, nil
+		},
+		"github_developers": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.GitHubTrendingDevelopersFetcher{Languages: ch.ConfigStrings("languages"), Since: ch.ConfigStrings("since")}, nil
+		},
+		"x_trends": func(ch storage.Channel) (collector.Fetcher, error) {
+			return &collector.XTrendsFetcher{Regions: ch.ConfigStrings("regions")}, nil
+		},
+		"feed":   newFeedFetcher,
+		"scrape": newScrapeFetcher,
+		"ashare_index": func(storage.Channel) (collector.Fetcher, error) {
+			return &collector.AShareIndexFetcher{
 				GetStockCodes: func() []string { return store.ListAShareStockCodes() },
 				HasTodayData: func(now time.Time) bool {
 					// 使用东八区日期与存储层保持一致
@@ -63,25 +109,75 @@ func main() {
 					date := now.In(loc).Format("2006-01-02")
 					return store.HasAshareDataForDate(date)
 				},
-			},
-			CronSpec: "*/3 * * * *",
+			}, nil
 		},
-		{Fetcher: &collector.HackerNewsFetcher{}, CronSpec: "0 * * * *"},
-		{Fetcher: &collector.GitHubTrendingMock{}, CronSpec: "0 */2 * * *"},
 	}
 
 	p := processor.NewSimpleProcessor()
-	s, err := scheduler.New(jobs, p, store)
+	// 过滤规则保存在 filter_rules 表，可通过 /api/v1/admin/filters 修改
+	filters := filter.New(store)
+	var locker scheduler.Locker
+	if cfg.FetchDistributedLock {
+		locker = store
+	}
+	s, err := scheduler.New(nil, p, store, scheduler.Options{
+		Retry: scheduler.RetryPolicy{
+			MaxAttempts:    cfg.FetchRetryMaxAttempts,
+			InitialBackoff: cfg.FetchRetryInitialBackoff,
+			MaxBackoff:     cfg.FetchRetryMaxBackoff,
+		},
+		Breaker: scheduler.BreakerConfig{
+			FailureThreshold: cfg.FetchBreakerThreshold,
+			OpenTimeout:      cfg.FetchBreakerOpenTimeout,
+		},
+		Overlap:    cfg.FetchOverlapPolicy,
+		Locker:     locker,
+		Factories:  factories,
+		Languages:  translateLangs(cfg.TranslateLangs),
+		Filter:     filters,
+		Enricher:   enricher.New(enricher.Config{MaxPerRun: cfg.EnrichMaxPerRun}, store),
+		Tagger:     newTagger(store, cfg),
+		Summarizer: newSummarizer(store, cfg),
+		Clusterer:  story.New(story.Config{Window: cfg.StoryWindow}, store),
+		// 订阅源 / 声明式抓取渠道的数据表在渠道保存后、重载时注册；停用的渠道也要注册，以便继续查询历史数据
+		Prepare: func(ch storage.Channel) error {
+			if ch.FetcherType != "feed" && ch.FetcherType != "scrape" {
+				return nil
+			}
+			return store.RegisterSource(ch.Code, dedicatedTable(ch))
+		},
+	})
 	if err != nil {
 		log.Fatalf("init scheduler failed: %v", err)
 	}
+	if err := s.Reload(); err != nil {
+		log.Fatalf("load fetcher jobs failed: %v", err)
+	}
 	s.Start()
 
+	// 定期从数据库重新加载渠道配置，多副本部署时其它副本上的修改最迟 1 分钟生效
+	if _, err := s.Cron().AddFunc("@every 1m", func() {
+		if err := s.Reload(); err != nil {
+			log.Printf("reload fetcher jobs error: %v", err)
+		}
+	}); err != nil {
+		log.Printf("warn: add channel reload cron failed: %v", err)
+	}
+
 	// 天气定时刷新：每小时从数据库读取城市列表并全量获取
-	if _, err := s.Cron().AddFunc("0 * * * *", func() { refreshWeather(store, cfg.QWeatherAPIKey, cfg.QWeatherAPIHost) }); err != nil {
+	if _, err := s.Cron().AddFunc("0 * * * *", func() { refreshWeather(ctx, store, cfg.QWeatherAPIKey, cfg.QWeatherAPIHost) }); err != nil {
 		log.Printf("warn: add weather cron failed: %v", err)
 	}
 
+	// 每天清理超过保留期的采集记录
+	if _, err := s.Cron().AddFunc("30 3 * * *", func() {
+		if err := store.PruneFetchRuns(); err != nil {
+			log.Printf("prune fetch runs error: %v", err)
+		}
+	}); err != nil {
+		log.Printf("warn: add prune fetch runs cron failed: %v", err)
+	}
+
 	// API
 	r := gin.Default()
 	// 若配置了全局访问密码，则启用 Basic Auth 保护（/health 仍然免认证）
@@ -89,7 +185,7 @@ func main() {
 		r.Use(basicAuthMiddleware(cfg.BasicAuthUser, cfg.BasicAuthPass))
 	}
 
-	apiServer := api.NewServer(store, cfg)
+	apiServer := api.NewServer(store, s, filters, cfg)
 	apiServer.RegisterRoutes(r)
 
 	// 若配置了前端目录，则托管 SPA 静态文件并做 fallback
@@ -107,13 +203,158 @@ func main() {
 		})
 	}
 	addr := ":" + cfg.AppPort
-	log.Printf("starting api server at %s ...", addr)
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("server exit: %v", err)
+	srv := &http.Server{Addr: addr, Handler: r}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("starting api server at %s ...", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Printf("shutdown signal received, waiting up to %v ...", cfg.ShutdownTimeout)
+	case err := <-serveErr:
+		log.Printf("server exit: %v", err)
 	}
+	stop()
+
+	shutdown(srv, s, apiServer, store, &weatherWG, cfg.ShutdownTimeout)
 }
 
-func refreshWeather(store *storage.Store, apiKey, apiHost string) {
+// defaultChannels 内置渠道及默认采集周期：
+// A 股指数 + 自选股每 3 分钟一次以获得更平滑的分时折线，单次执行必须在下一次 tick 前结束；
+// 收盘后仅在“当天尚无任何 A 股数据”时允许再拉一次，用当前价回填当天快照。
+// Hacker News 默认抓取 top / best / ask / show / jobs 五个榜单并附带每条前 3 条评论，可通过 config.lists、config.perList、config.comments 调整。
+// Reddit 默认抓取 programming / golang / technology 三个版块，可通过 config.subreddits 调整。
+// arXiv 默认抓取 cs.AI / cs.CL / cs.DC 三个分类的最新论文，可通过 config.categories 调整。
+// X 趋势默认抓取全球、中国、日本、美国四个地区，可通过 config.regions 调整（取值为 trends24.in 的地区路径）。
+var defaultChannels = []storage.Channel{
+	{Code: "github", Name: "GitHub Trending", BaseURL: "https://github.com/trending", Status: storage.ChannelActive,
+		FetcherType: "github_trending", CronSpec: "0 */2 * * *", TimeoutSec: 300,
+		Config: datatypes.JSONMap{"languages": []string{"all", "go", "rust", "typescript"}, "since": []string{"daily", "weekly"}, "enrich": true}},
+	{Code: "github_developers", Name: "GitHub 开发者", BaseURL: "https://github.com/trending/developers", Status: storage.ChannelActive,
+		FetcherType: "github_developers", CronSpec: "30 */6 * * *", TimeoutSec: 120,
+		Config: datatypes.JSONMap{"since": []string{"daily", "weekly"}}},
+	{Code: "baidu", Name: "百度热搜", BaseURL: "https://top.baidu.com/board?tab=realtime", Status: storage.ChannelActive,
+		FetcherType: "baidu_hot", CronSpec: "*/30 * * * *"},
+	{Code: "weibo", Name: "微博热搜", BaseURL: "https://s.weibo.com/top/summary", Status: storage.ChannelActive,
+		FetcherType: "weibo_hot", CronSpec: "*/15 * * * *"},
+	{Code: "zhihu", Name: "知乎热榜", BaseURL: "https://www.zhihu.com/hot", Status: storage.ChannelActive,
+		FetcherType: "zhihu_hot", CronSpec: "*/30 * * * *"},
+	{Code: "bilibili", Name: "B 站热门", BaseURL: "https://www.bilibili.com/v/popular/all", Status: storage.ChannelActive,
+		FetcherType: "bilibili_popular", CronSpec: "0 * * * *"},
+	{Code: "douyin", Name: "抖音热榜", BaseURL: "https://www.douyin.com/hot", Status: storage.ChannelActive,
+		FetcherType: "douyin_hot", CronSpec: "*/30 * * * *"},
+	{Code: "gold", Name: "金融", Status: storage.ChannelActive,
+		FetcherType: "gold_price", CronSpec: "*/30 * * * *"},
+	{Code: "ashare", Name: "A 股", BaseURL: "https://quote.eastmoney.com", Status: storage.ChannelActive,
+		FetcherType: "ashare_index", CronSpec: "*/3 * * * *", TimeoutSec: 120},
+	{Code: "hackernews", Name: "Hacker News", BaseURL: "https://news.ycombinator.com", Status: storage.ChannelActive,
+		FetcherType: "hackernews", CronSpec: "0 * * * *", TimeoutSec: 300,
+		Config: datatypes.JSONMap{"lists": []string{"top", "best", "ask", "show", "jobs"}, "comments": 3, "enrich": true}},
+	{Code: "v2ex", Name: "V2EX", BaseURL: "https://www.v2ex.com/?tab=hot", Status: storage.ChannelActive,
+		FetcherType: "v2ex_hot", CronSpec: "*/30 * * * *", TimeoutSec: 120},
+	{Code: "lobsters", Name: "Lobsters", BaseURL: "https://lobste.rs", Status: storage.ChannelActive,
+		FetcherType: "lobsters_hottest", CronSpec: "15 * * * *", TimeoutSec: 120},
+	{Code: "reddit", Name: "Reddit", BaseURL: "https://www.reddit.com", Status: storage.ChannelActive,
+		FetcherType: "reddit_hot", CronSpec: "45 * * * *", TimeoutSec: 180,
+		Config: datatypes.JSONMap{"subreddits": []string{"programming", "golang", "technology"}}},
+	{Code: "producthunt", Name: "Product Hunt", BaseURL: "https://www.producthunt.com", Status: storage.ChannelActive,
+		FetcherType: "producthunt_daily", CronSpec: "0 */3 * * *", TimeoutSec: 120},
+	{Code: "arxiv", Name: "arXiv", BaseURL: "https://arxiv.org", Status: storage.ChannelActive,
+		FetcherType: "arxiv", CronSpec: "0 */6 * * *", TimeoutSec: 300,
+		Config: datatypes.JSONMap{"categories": []string{"cs.AI", "cs.CL", "cs.DC"}}},
+	{Code: "papers", Name: "热门论文", BaseURL: "https://huggingface.co/papers/trending", Status: storage.ChannelActive,
+		FetcherType: "papers_trending", CronSpec: "20 */3 * * *", TimeoutSec: 300},
+	{Code: "x", Name: "X 趋势", BaseURL: "https://trends24.in", Status: storage.ChannelActive,
+		FetcherType: "x_trends", CronSpec: "0 * * * *", TimeoutSec: 180,
+		Config: datatypes.JSONMap{"regions": []string{"worldwide", "china", "japan", "united-states"}}},
+}
+
+// newFeedFetcher 按渠道配置创建 RSS / Atom / JSON Feed 采集器：config.urls（或 config.url）为订阅地址，
+// config.table 为 dedicated 时单独建表 news_<code>，否则写入共享表 news_feed；数据表在渠道保存后由 Prepare 注册，这里只校验
+func newFeedFetcher(ch storage.Channel) (collector.Fetcher, error) {
+	urls := append(ch.ConfigStrings("urls"), ch.ConfigStrings("url")...)
+	if len(urls) == 0 {
+		return nil, errors.New("feed: config.urls is required")
+	}
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("feed: invalid url %q", u)
+		}
+	}
+	if err := storage.CheckSource(ch.Code, dedicatedTable(ch)); err != nil {
+		return nil, err
+	}
+	return &collector.FeedFetcher{Source: ch.Code, URLs: urls}, nil
+}
+
+// newScrapeFetcher 按渠道配置中的声明式抓取定义（见 collector.ScrapeSpec）创建采集器，数据表规则同 newFeedFetcher
+func newScrapeFetcher(ch storage.Channel) (collector.Fetcher, error) {
+	spec, err := collector.ParseScrapeSpec(ch.Config)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.CheckSource(ch.Code, dedicatedTable(ch)); err != nil {
+		return nil, err
+	}
+	return &collector.ScrapeFetcher{Source: ch.Code, Spec: spec}, nil
+}
+
+// dedicatedTable 渠道是否配置为单独建表
+func dedicatedTable(ch storage.Channel) bool {
+	return ch.Config["table"] == "dedicated"
+}
+
+// shutdown 在 timeout 内依次完成退出：停止接收新请求并等待进行中的请求、停止 cron 并等待进行中的采集
+// （超时则取消其 context）、等待后台天气刷新，最后关闭数据库与 Redis 连接
+func shutdown(srv *http.Server, s *scheduler.Scheduler, apiServer *api.Server, store *storage.Store, weatherWG *sync.WaitGroup, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// HTTP 与调度器并行退出：手动触发的同步采集既占用请求也计入调度器的进行中任务
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("shutdown: http server: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := s.Stop(ctx); err != nil {
+			log.Printf("shutdown: scheduler: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	if err := apiServer.Close(ctx); err != nil {
+		log.Printf("shutdown: api background tasks: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		weatherWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("shutdown: weather refresh still running, giving up")
+	}
+
+	if err := store.Close(); err != nil {
+		log.Printf("shutdown: close store: %v", err)
+	}
+	log.Println("shutdown complete")
+}
+
+// refreshWeather 全量刷新关注城市的天气缓存；ctx 取消（进程退出）时放弃尚未完成的请求
+func refreshWeather(ctx context.Context, store *storage.Store, apiKey, apiHost string) {
 	if apiKey == "" || apiHost == "" {
 		log.Printf("weather: skip refresh, QWeather not configured")
 		return
@@ -133,7 +374,7 @@ func refreshWeather(store *storage.Store, apiKey, apiHost string) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 			defer cancel()
 			data, err := api.FetchWeatherFromQWeather(ctx, city, apiKey, apiHost)
 			if err != nil {
@@ -175,3 +416,81 @@ func basicAuthMiddleware(user, pass string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// translateLangs 规范化 TRANSLATE_LANGS，忽略非法与重复的语言代码
+func translateLangs(langs []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, l := range langs {
+		lang, ok := collector.NormalizeLang(l)
+		if !ok {
+			log.Printf("warn: invalid TRANSLATE_LANGS entry %q", l)
+			continue
+		}
+		if lang == "zh" || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		out = append(out, lang)
+	}
+	return out
+}
+
+// configureTranslation 按 TRANSLATE_PROVIDERS 构建翻译回退链；缺少配置的提供方记录警告后跳过，全部不可用时使用默认链
+func configureTranslation(store *storage.Store, cfg *config.Config) {
+	tcfg := collector.TranslatorConfig{
+		DeepLAPIKey:          cfg.DeepLAPIKey,
+		DeepLAPIURL:          cfg.DeepLAPIURL,
+		LLMAPIURL:            cfg.LLMAPIURL,
+		LLMAPIKey:            cfg.LLMAPIKey,
+		LLMModel:             cfg.LLMModel,
+		LibreTranslateURL:    cfg.LibreTranslateURL,
+		LibreTranslateAPIKey: cfg.LibreTranslateAPIKey,
+	}
+	var chain []collector.Translator
+	var names []string
+	for _, name := range cfg.TranslateProviders {
+		t, err := collector.NewTranslator(name, tcfg)
+		if err != nil {
+			log.Printf("warn: translator %s: %v", name, err)
+			continue
+		}
+		chain = append(chain, t)
+		names = append(names, t.Name())
+	}
+	collector.SetTranslators(chain)
+	collector.SetTranslationCache(store)
+	if len(names) > 0 {
+		log.Printf("translators: %s", strings.Join(names, " -> "))
+	}
+}
+
+// newTagger 创建打标签服务：始终按词典打标签；TAG_CLASSIFY 开启且配置了 LLM_API_URL 与 LLM_MODEL 时，词典未命中的条目交给大模型分类
+func newTagger(store *storage.Store, cfg *config.Config) *tagger.Tagger {
+	dict, err := tagger.LoadDictionary(cfg.TagDictFile)
+	if err != nil {
+		log.Fatalf("load tag dictionary failed: %v", err)
+	}
+	tc := tagger.Config{Dictionary: dict, MaxPerRun: cfg.TagClassifyMaxPerRun}
+	if cfg.TagClassify && cfg.LLMAPIURL != "" && cfg.LLMModel != "" {
+		tc.BaseURL, tc.APIKey, tc.Model = cfg.LLMAPIURL, cfg.LLMAPIKey, cfg.LLMModel
+		log.Printf("tagger: classifier model=%s", cfg.LLMModel)
+	}
+	return tagger.New(tc, store)
+}
+
+// newSummarizer 配置了 LLM_API_URL 与 LLM_MODEL 时创建摘要服务，摘要按条目缓存在 PostgreSQL / Redis
+func newSummarizer(store *storage.Store, cfg *config.Config) scheduler.Summarizer {
+	sum := summarizer.New(summarizer.Config{
+		BaseURL:       cfg.LLMAPIURL,
+		APIKey:        cfg.LLMAPIKey,
+		Model:         cfg.LLMModel,
+		RatePerMinute: cfg.SummaryRatePerMinute,
+		MaxPerRun:     cfg.SummaryMaxPerRun,
+	}, store)
+	if sum == nil {
+		return nil
+	}
+	log.Printf("summarizer: model=%s", cfg.LLMModel)
+	return sum
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/LJTian/TrendingHub/internal/scheduler"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ========== 管理接口：采集运行记录 ==========
+
+// listFetchRuns 分页返回采集执行记录，可按 fetcher 过滤
+func (s *Server) listFetchRuns(c *gin.Context) {
+	fetcher := c.Query("fetcher")
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 200 {
+		pageSize = 200
+	}
+
+	runs, total, err := s.store.ListFetchRuns(fetcher, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":     "ok",
+		"message":  "success",
+		"data":     runs,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// summarizeFetchRuns 返回每个数据源最近一次成功时间与连续失败次数
+func (s *Server) summarizeFetchRuns(c *gin.Context) {
+	list, err := s.store.SummarizeFetchRuns()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": list})
+}
+
+// getFetchRun 返回单条采集记录，用于轮询异步手动触发的结果
+func (s *Server) getFetchRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid run id"})
+		return
+	}
+	run, err := s.store.GetFetchRun(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "run not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": run})
+}
+
+// ========== 管理接口：采集任务 ==========
+
+// listFetchers 返回所有已注册的采集任务、是否正在执行以及熔断器状态
+func (s *Server) listFetchers(c *gin.Context) {
+	type item struct {
+		Name     string                  `json:"name"`
+		Channel  string                  `json:"channel,omitempty"`
+		CronSpec string                  `json:"cronSpec"`
+		Timeout  string                  `json:"timeout"`
+		MaxItems int                     `json:"maxItems"`
+		Running  bool                    `json:"running"`
+		Breaker  scheduler.BreakerStatus `json:"breaker"`
+	}
+	jobs := s.scheduler.Jobs()
+	items := make([]item, 0, len(jobs))
+	for _, j := range jobs {
+		name := j.Fetcher.Name()
+		breaker, _ := s.scheduler.BreakerStatus(name)
+		items = append(items, item{
+			Name:     name,
+			Channel:  j.Channel,
+			CronSpec: j.CronSpec,
+			Timeout:  j.EffectiveTimeout().String(),
+			MaxItems: j.MaxItems,
+			Running:  s.scheduler.IsRunning(name),
+			Breaker:  breaker,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": items})
+}
+
+// runFetcher 手动触发单个采集任务。默认同步执行并返回运行结果；
+// async=true 时立即返回运行记录 ID（202），可通过 GET /api/v1/admin/runs/:id 轮询。
+func (s *Server) runFetcher(c *gin.Context) {
+	name := c.Param("name")
+	async := c.Query("async") == "true" || c.Query("async") == "1"
+
+	if async {
+		id, err := s.scheduler.StartJob(name)
+		if err != nil {
+			s.respondRunError(c, name, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"code": "ok", "message": "run started", "data": gin.H{"runId": id}})
+		return
+	}
+
+	run, err := s.scheduler.RunJob(name)
+	if err != nil {
+		s.respondRunError(c, name, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": run})
+}
+
+// resetFetcherBreaker 手动将指定采集任务的熔断器恢复为 closed
+func (s *Server) resetFetcherBreaker(c *gin.Context) {
+	name := c.Param("name")
+	if err := s.scheduler.ResetBreaker(name); err != nil {
+		s.respondRunError(c, name, err)
+		return
+	}
+	status, _ := s.scheduler.BreakerStatus(name)
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "breaker reset", "data": status})
+}
+
+func (s *Server) respondRunError(c *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "fetcher not found: " + name})
+	case errors.Is(err, scheduler.ErrJobRunning):
+		c.JSON(http.StatusConflict, gin.H{"code": "conflict", "message": "fetcher is already running: " + name})
+	case errors.Is(err, scheduler.ErrJobLocked):
+		c.JSON(http.StatusConflict, gin.H{"code": "conflict", "message": "fetcher is running on another replica: " + name})
+	case errors.Is(err, scheduler.ErrStopped):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"code": "unavailable", "message": "server is shutting down"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+	}
+}
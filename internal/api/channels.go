@@ -0,0 +1,176 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/LJTian/TrendingHub/internal/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ========== 管理接口：渠道与采集配置 ==========
+
+var channelCodePattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// channelBody 新增 / 修改渠道的请求体；修改时未出现的字段保持不变
+type channelBody struct {
+	Code        string  `json:"code"`
+	Name        *string `json:"name"`
+	BaseURL     *string `json:"baseUrl"`
+	Status      *string `json:"status"`
+	FetcherType *string `json:"fetcherType"`
+	CronSpec    *string `json:"cronSpec"`
+	TimeoutSec  *int    `json:"timeoutSec"`
+	MaxItems    *int    `json:"maxItems"`
+	// Config 出现时整体替换采集器参数，传 {} 可清空
+	Config map[string]any `json:"config"`
+}
+
+func (b channelBody) applyTo(ch *storage.Channel) {
+	if b.Name != nil {
+		ch.Name = strings.TrimSpace(*b.Name)
+	}
+	if b.BaseURL != nil {
+		ch.BaseURL = strings.TrimSpace(*b.BaseURL)
+	}
+	if b.Status != nil {
+		ch.Status = *b.Status
+	}
+	if b.FetcherType != nil {
+		ch.FetcherType = strings.TrimSpace(*b.FetcherType)
+	}
+	if b.CronSpec != nil {
+		ch.CronSpec = strings.TrimSpace(*b.CronSpec)
+	}
+	if b.TimeoutSec != nil {
+		ch.TimeoutSec = *b.TimeoutSec
+	}
+	if b.MaxItems != nil {
+		ch.MaxItems = *b.MaxItems
+	}
+	if b.Config != nil {
+		ch.Config = datatypes.JSONMap(b.Config)
+	}
+}
+
+// validateChannel 校验状态取值，并由调度器确认采集器类型、cron 表达式等能构造出任务
+func (s *Server) validateChannel(ch storage.Channel) error {
+	if ch.Status != storage.ChannelActive && ch.Status != storage.ChannelDisabled {
+		return errors.New("status must be active or disabled")
+	}
+	return s.scheduler.ValidateChannel(ch)
+}
+
+// reloadJobs 渠道变更后立即重新加载采集任务；失败只记日志，配置已保存，定时重载时会再次尝试
+func (s *Server) reloadJobs() {
+	if err := s.scheduler.Reload(); err != nil {
+		log.Printf("reload fetcher jobs error: %v", err)
+	}
+}
+
+// listChannels 返回所有渠道及可用的采集器类型与处理阶段
+func (s *Server) listChannels(c *gin.Context) {
+	list, err := s.store.ListChannels()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": list, "fetcherTypes": s.scheduler.FetcherTypes(), "stages": s.scheduler.StageNames()})
+}
+
+// getChannel 返回单个渠道
+func (s *Server) getChannel(c *gin.Context) {
+	ch, err := s.store.GetChannel(c.Param("code"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "channel not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": ch})
+}
+
+// createChannel 新增渠道，status 缺省为 active；保存后立即生效
+func (s *Server) createChannel(c *gin.Context) {
+	var body channelBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid body"})
+		return
+	}
+	code := strings.TrimSpace(body.Code)
+	if !channelCodePattern.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "code must match [a-z0-9_]{1,64}"})
+		return
+	}
+	ch := storage.Channel{Code: code, Status: storage.ChannelActive}
+	body.applyTo(&ch)
+	if err := s.validateChannel(ch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+
+	if _, err := s.store.GetChannel(code); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"code": "conflict", "message": "channel already exists: " + code})
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	if err := s.store.CreateChannel(&ch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	s.reloadJobs()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "channel created", "data": ch})
+}
+
+// updateChannel 修改渠道配置（含启用 / 停用），只更新请求体中出现的字段；保存后立即生效
+func (s *Server) updateChannel(c *gin.Context) {
+	var body channelBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid body"})
+		return
+	}
+	ch, err := s.store.GetChannel(c.Param("code"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "channel not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	body.applyTo(ch)
+	if err := s.validateChannel(*ch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+	if err := s.store.SaveChannel(ch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	s.reloadJobs()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "channel updated", "data": ch})
+}
+
+// deleteChannel 删除渠道配置并停止其采集，已采集的数据保留
+func (s *Server) deleteChannel(c *gin.Context) {
+	ok, err := s.store.DeleteChannel(c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "channel not found"})
+		return
+	}
+	s.reloadJobs()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "channel deleted"})
+}
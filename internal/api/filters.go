@@ -0,0 +1,223 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LJTian/TrendingHub/internal/filter"
+	"github.com/LJTian/TrendingHub/internal/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ========== 管理接口：过滤规则 ==========
+
+// filterRuleBody 新增 / 修改过滤规则的请求体；修改时未出现的字段保持不变
+type filterRuleBody struct {
+	Channel   *string `json:"channel"`
+	Action    *string `json:"action"`
+	MatchType *string `json:"matchType"`
+	Pattern   *string `json:"pattern"`
+	Field     *string `json:"field"`
+	Enabled   *bool   `json:"enabled"`
+	Note      *string `json:"note"`
+	// Limit 仅用于预览：检查最近多少条已入库的条目
+	Limit *int `json:"limit"`
+}
+
+func (b filterRuleBody) applyTo(r *storage.FilterRule) {
+	if b.Channel != nil {
+		r.Channel = strings.TrimSpace(*b.Channel)
+	}
+	if b.Action != nil {
+		r.Action = strings.TrimSpace(*b.Action)
+	}
+	if b.MatchType != nil {
+		r.MatchType = strings.TrimSpace(*b.MatchType)
+	}
+	if b.Pattern != nil {
+		r.Pattern = *b.Pattern
+	}
+	if b.Field != nil {
+		r.Field = strings.TrimSpace(*b.Field)
+	}
+	if b.Enabled != nil {
+		r.Enabled = *b.Enabled
+	}
+	if b.Note != nil {
+		r.Note = strings.TrimSpace(*b.Note)
+	}
+}
+
+// compileFilterRule 校验渠道 code 并编译规则；返回的规则已补齐 action / matchType / field 的默认值
+func compileFilterRule(r storage.FilterRule) (*filter.Rule, error) {
+	if ch := strings.TrimSpace(r.Channel); ch != "" && !channelCodePattern.MatchString(ch) {
+		return nil, errors.New("channel must match [a-z0-9_]{1,64}")
+	}
+	return filter.Compile(r)
+}
+
+// invalidateFilters 规则变更后让采集立即使用新规则
+func (s *Server) invalidateFilters() {
+	if s.filter != nil {
+		s.filter.Invalidate()
+	}
+}
+
+// filterRuleID 解析路径中的规则 ID，非法时写入 400 响应并返回 false
+func filterRuleID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid rule id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// listFilterRules 返回过滤规则；channel 参数非空时只返回该渠道与全局规则
+func (s *Server) listFilterRules(c *gin.Context) {
+	list, err := s.store.ListFilterRules(strings.TrimSpace(c.Query("channel")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": list})
+}
+
+// getFilterRule 返回单条过滤规则
+func (s *Server) getFilterRule(c *gin.Context) {
+	id, ok := filterRuleID(c)
+	if !ok {
+		return
+	}
+	r, err := s.store.GetFilterRule(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "filter rule not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": r})
+}
+
+// createFilterRule 新增过滤规则，enabled 缺省为 true；保存后立即生效
+func (s *Server) createFilterRule(c *gin.Context) {
+	var body filterRuleBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid body"})
+		return
+	}
+	r := storage.FilterRule{Enabled: true}
+	body.applyTo(&r)
+	compiled, err := compileFilterRule(r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+	r = compiled.FilterRule
+	if err := s.store.CreateFilterRule(&r); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	s.invalidateFilters()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "filter rule created", "data": r})
+}
+
+// updateFilterRule 修改过滤规则，只更新请求体中出现的字段；保存后立即生效
+func (s *Server) updateFilterRule(c *gin.Context) {
+	id, ok := filterRuleID(c)
+	if !ok {
+		return
+	}
+	var body filterRuleBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid body"})
+		return
+	}
+	r, err := s.store.GetFilterRule(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "filter rule not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	body.applyTo(r)
+	compiled, err := compileFilterRule(*r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+	*r = compiled.FilterRule
+	if err := s.store.SaveFilterRule(r); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	s.invalidateFilters()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "filter rule updated", "data": r})
+}
+
+// deleteFilterRule 删除过滤规则，已被过滤掉的条目不会恢复
+func (s *Server) deleteFilterRule(c *gin.Context) {
+	id, ok := filterRuleID(c)
+	if !ok {
+		return
+	}
+	deleted, err := s.store.DeleteFilterRule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": err.Error()})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "filter rule not found"})
+		return
+	}
+	s.invalidateFilters()
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "filter rule deleted"})
+}
+
+// previewFilterRule 用请求体中的规则检查该渠道（channel 为空时为所有渠道）最近 limit 条（默认 200，最多 1000）已入库的条目，
+// 返回单独使用该规则时会被去掉的条目，不保存规则
+func (s *Server) previewFilterRule(c *gin.Context) {
+	var body filterRuleBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid body"})
+		return
+	}
+	var r storage.FilterRule
+	body.applyTo(&r)
+	rule, err := compileFilterRule(r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+	limit := 200
+	if body.Limit != nil && *body.Limit > 0 {
+		limit = min(*body.Limit, 1000)
+	}
+
+	list, err := s.store.ListNews(storage.NewsQuery{Channel: rule.Channel, Sort: "latest", Limit: limit})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	dropped := make([]storage.News, 0)
+	for _, n := range list {
+		if rule.Drops(n.Title, n.OriginalTitle, n.Description, n.OriginalDescription) {
+			dropped = append(dropped, n)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    "ok",
+		"message": "success",
+		"data": gin.H{
+			"checked": len(list),
+			"dropped": dropped,
+		},
+	})
+}
@@ -2,19 +2,29 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/LJTian/TrendingHub/internal/collector"
 	"github.com/LJTian/TrendingHub/internal/config"
+	"github.com/LJTian/TrendingHub/internal/enricher"
+	"github.com/LJTian/TrendingHub/internal/filter"
+	"github.com/LJTian/TrendingHub/internal/scheduler"
 	"github.com/LJTian/TrendingHub/internal/storage"
+	"github.com/LJTian/TrendingHub/internal/tagger"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 var httpClient = &http.Client{
@@ -23,15 +33,48 @@ var httpClient = &http.Client{
 
 type Server struct {
 	store          *storage.Store
+	scheduler      *scheduler.Scheduler
+	filter         *filter.Filter
 	qWeatherHost   string
 	qWeatherAPIKey string
+	// adminToken 管理接口所需的令牌，为空时管理接口全部拒绝
+	adminToken string
+
+	// 请求触发的后台任务（如添加城市后预取天气）共用的 context，Close 时取消并等待其退出
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+	bgWG     sync.WaitGroup
 }
 
-func NewServer(store *storage.Store, cfg *config.Config) *Server {
+// NewServer 创建 HTTP 接口；filters 可为 nil，不为空时修改过滤规则后立即让采集使用新规则
+func NewServer(store *storage.Store, sched *scheduler.Scheduler, filters *filter.Filter, cfg *config.Config) *Server {
+	bgCtx, bgCancel := context.WithCancel(context.Background())
 	return &Server{
 		store:          store,
+		scheduler:      sched,
+		filter:         filters,
 		qWeatherHost:   cfg.QWeatherAPIHost,
 		qWeatherAPIKey: cfg.QWeatherAPIKey,
+		adminToken:     cfg.AdminToken,
+		bgCtx:          bgCtx,
+		bgCancel:       bgCancel,
+	}
+}
+
+// Close 等待后台任务结束；ctx 到期时取消仍未完成的任务并返回 ctx.Err()
+func (s *Server) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.bgWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		s.bgCancel()
+		return nil
+	case <-ctx.Done():
+		s.bgCancel()
+		return ctx.Err()
 	}
 }
 
@@ -42,6 +85,8 @@ func (s *Server) RegisterRoutes(r *gin.Engine) {
 	{
 		v1.GET("/news/dates", s.listNewsDates)
 		v1.GET("/news", s.listNews)
+		v1.GET("/news/:id", s.getNews)
+		v1.GET("/stories", s.listStories)
 
 		v1.GET("/weather", s.getWeather)
 		v1.GET("/weather/cities", s.listWeatherCities)
@@ -51,7 +96,47 @@ func (s *Server) RegisterRoutes(r *gin.Engine) {
 		v1.GET("/ashare/stocks", s.listAshareStocks)
 		v1.POST("/ashare/stocks", s.addAshareStock)
 		v1.DELETE("/ashare/stocks/:code", s.removeAshareStock)
+
+		admin := v1.Group("/admin", s.requireAdmin)
+		admin.GET("/runs", s.listFetchRuns)
+		admin.GET("/runs/summary", s.summarizeFetchRuns)
+		admin.GET("/runs/:id", s.getFetchRun)
+		admin.GET("/fetchers", s.listFetchers)
+		admin.POST("/fetchers/:name/run", s.runFetcher)
+		admin.POST("/fetchers/:name/breaker/reset", s.resetFetcherBreaker)
+		admin.GET("/channels", s.listChannels)
+		admin.POST("/channels", s.createChannel)
+		admin.GET("/channels/:code", s.getChannel)
+		admin.PATCH("/channels/:code", s.updateChannel)
+		admin.DELETE("/channels/:code", s.deleteChannel)
+		admin.POST("/scrape/dry-run", s.dryRunScrape)
+		admin.GET("/filters", s.listFilterRules)
+		admin.POST("/filters", s.createFilterRule)
+		admin.POST("/filters/preview", s.previewFilterRule)
+		admin.GET("/filters/:id", s.getFilterRule)
+		admin.PATCH("/filters/:id", s.updateFilterRule)
+		admin.DELETE("/filters/:id", s.deleteFilterRule)
+	}
+}
+
+// requireAdmin 校验管理接口令牌：请求头 X-Admin-Token 或 Authorization: Bearer <令牌>。
+// 管理接口可以修改采集配置并让服务端请求任意链接，未配置 ADMIN_TOKEN 时一律拒绝
+func (s *Server) requireAdmin(c *gin.Context) {
+	if s.adminToken == "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"code": "forbidden", "message": "admin api is disabled, set ADMIN_TOKEN to enable it"})
+		return
+	}
+	token := c.GetHeader("X-Admin-Token")
+	if token == "" {
+		if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": "unauthorized", "message": "invalid admin token"})
+		return
 	}
+	c.Next()
 }
 
 func (s *Server) health(c *gin.Context) {
@@ -120,12 +205,14 @@ func (s *Server) addWeatherCity(c *gin.Context) {
 	}
 
 	// 立即获取天气并缓存，这样前端刷新就能看到
+	s.bgWG.Add(1)
 	go func() {
+		defer s.bgWG.Done()
 		if s.qWeatherHost == "" || s.qWeatherAPIKey == "" {
 			log.Printf("weather: QWeather config missing, skip fetch for %s", city)
 			return
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		ctx, cancel := context.WithTimeout(s.bgCtx, 20*time.Second)
 		defer cancel()
 		data, err := FetchWeatherFromQWeather(ctx, city, s.qWeatherAPIKey, s.qWeatherHost)
 		if err != nil {
@@ -484,7 +571,62 @@ func (s *Server) listNews(c *gin.Context) {
 		limit = maxLimit
 	}
 
-	items, err := s.store.ListNews(channel, sort, limit, date)
+	q := storage.NewsQuery{Channel: channel, Sort: sort, Limit: limit, Date: date, Extra: map[string]string{}}
+	// region：X 趋势按地区过滤（如 japan、united-states），匹配话题出现过的任一地区
+	if region := strings.ToLower(strings.TrimSpace(c.Query("region"))); region != "" {
+		if len(region) > 64 {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid region"})
+			return
+		}
+		q.Extra["regions"] = region
+	}
+	// language / since：GitHub Trending 按仓库语言（如 go、typescript）与榜单时间范围过滤
+	if language := strings.TrimSpace(c.Query("language")); language != "" {
+		if len(language) > 64 {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid language"})
+			return
+		}
+		q.Extra["languageKey"] = collector.GitHubLanguageKey(language)
+	}
+	if since := strings.ToLower(strings.TrimSpace(c.Query("since"))); since != "" {
+		if !collector.IsGitHubSince(since) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid since, expected daily, weekly or monthly"})
+			return
+		}
+		q.Extra["since"] = since
+	}
+	// list：Hacker News 按榜单过滤（top / best / new / ask / show / jobs），匹配故事出现过的任一榜单
+	if list := c.Query("list"); list != "" {
+		name, ok := collector.HNListName(list)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid list, expected top, best, new, ask, show or jobs"})
+			return
+		}
+		q.Extra["lists"] = name
+	}
+	// category：arXiv / 热门论文按分类过滤（如 cs.AI，大小写不敏感），匹配论文的任一分类
+	if category := c.Query("category"); category != "" {
+		key, ok := collector.ArxivCategoryKey(category)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid category, expected an arXiv category such as cs.AI"})
+			return
+		}
+		q.Extra["categoryKeys"] = key
+	}
+	// tag：按主题标签过滤（如 ai、finance），跨渠道可用
+	if tag := strings.ToLower(strings.TrimSpace(c.Query("tag"))); tag != "" {
+		if !tagger.TagPattern.MatchString(tag) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid tag"})
+			return
+		}
+		q.Tag = tag
+	}
+	lang, ok := queryLang(c)
+	if !ok {
+		return
+	}
+
+	items, err := s.store.ListNews(q)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    "internal_error",
@@ -492,11 +634,114 @@ func (s *Server) listNews(c *gin.Context) {
 		})
 		return
 	}
+	for i := range items {
+		items[i].Localize(lang)
+	}
+	// 标签分面：该渠道（与日期）下各标签的条目数，不受 tag 及其它过滤条件影响；统计失败时返回空列表
+	facets, err := s.store.TagFacets(channel, date)
+	if err != nil {
+		log.Printf("list news: tag facets: %v", err)
+		facets = []storage.TagCount{}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"code":    "ok",
 		"message": "success",
 		"data":    items,
+		"tags":    facets,
+	})
+}
+
+// queryLang 解析 lang 参数：返回的 title / description 使用的语言，默认中文；original 为原文，
+// 其它语言取采集时生成的译文，缺失时回退为原文。参数非法时写入 400 响应并返回 false
+func queryLang(c *gin.Context) (string, bool) {
+	lang := strings.TrimSpace(c.Query("lang"))
+	if lang == "" || lang == storage.LangOriginal {
+		return lang, true
+	}
+	normalized, ok := collector.NormalizeLang(lang)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid lang, expected original or a language code such as en"})
+		return "", false
+	}
+	return normalized, true
+}
+
+var newsIDRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// getNews 返回单条新闻及其链接预览（OpenGraph 元数据、canonical 链接与正文节选，仅开启 enrich 的渠道有值）；
+// 同一链接被多个渠道收录时 ID 相同，需用 channel 参数指定渠道，否则返回 409 及候选渠道
+func (s *Server) getNews(c *gin.Context) {
+	id := c.Param("id")
+	if !newsIDRe.MatchString(id) {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid id"})
+		return
+	}
+	lang, ok := queryLang(c)
+	if !ok {
+		return
+	}
+	n, err := s.store.GetNews(id, c.Query("channel"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "not_found", "message": "news not found"})
+		return
+	}
+	var ambiguous *storage.AmbiguousNewsError
+	if errors.As(err, &ambiguous) {
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    "conflict",
+			"message": "news is listed by multiple channels, specify channel",
+			"data":    gin.H{"channels": ambiguous.Sources},
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	n.Localize(lang)
+	c.JSON(http.StatusOK, gin.H{
+		"code":    "ok",
+		"message": "success",
+		"data": gin.H{
+			"news":    n,
+			"preview": n.ExtraData[enricher.PreviewKey],
+		},
+	})
+}
+
+// listStories 返回最近 days 天（默认 3，最多 30）内仍在更新的跨数据源 story，按合计热度倒序；
+// minSources 只返回至少覆盖这么多个数据源的 story（默认 1）
+func (s *Server) listStories(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", "3"))
+	if err != nil || days <= 0 {
+		days = 3
+	}
+	if days > 30 {
+		days = 30
+	}
+	minSources, err := strconv.Atoi(c.DefaultQuery("minSources", "1"))
+	if err != nil || minSources < 1 {
+		minSources = 1
+	}
+
+	stories, err := s.store.ListStories(storage.StoryQuery{
+		Since:      time.Now().AddDate(0, 0, -days),
+		MinSources: minSources,
+		Limit:      limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "internal_error", "message": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    "ok",
+		"message": "success",
+		"data":    stories,
 	})
 }
 
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== 管理接口：声明式抓取定义 ==========
+
+// scrapeDryRunTimeout 试运行的总超时，避免慢站点长时间占用请求
+const scrapeDryRunTimeout = 30 * time.Second
+
+// dryRunScrape 按请求体中的抓取定义（与 scrape 渠道的 config 相同）执行一次抓取，返回解析出的条目，不写入数据库
+func (s *Server) dryRunScrape(c *gin.Context) {
+	var body map[string]any
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": "invalid request body"})
+		return
+	}
+	spec, err := collector.ParseScrapeSpec(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": "bad_request", "message": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), scrapeDryRunTimeout)
+	defer cancel()
+	f := &collector.ScrapeFetcher{Source: "dry_run", Spec: spec}
+	items, err := f.FetchContext(ctx)
+	if err != nil {
+		status, code := http.StatusBadGateway, "fetch_failed"
+		if errors.Is(err, context.DeadlineExceeded) {
+			status, code = http.StatusGatewayTimeout, "timeout"
+		}
+		c.JSON(status, gin.H{"code": code, "message": err.Error()})
+		return
+	}
+
+	data := make([]gin.H, 0, len(items))
+	for _, it := range items {
+		data = append(data, gin.H{
+			"title":       it.Title,
+			"url":         it.URL,
+			"description": it.Description,
+			"publishedAt": it.PublishedAt,
+			"hotScore":    it.HotScore,
+			"extraData":   it.RawData,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"code": "ok", "message": "success", "data": data, "spec": spec})
+}
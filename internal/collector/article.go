@@ -0,0 +1,155 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// ArticleMaxBodyBytes 抓取文章正文页面的默认读取上限
+	ArticleMaxBodyBytes   = 2 << 20 // 2MB
+	articleRequestTimeout = 15 * time.Second
+	articleMaxTextRunes   = 20000
+	// articleMinParagraphRunes 参与正文打分的段落最少字数，过短的多为导航、按钮文字
+	articleMinParagraphRunes = 25
+)
+
+// ErrNotHTML 链接指向的不是 HTML 页面（如 PDF、图片），无法提取正文
+var ErrNotHTML = errors.New("article: not an html page")
+
+// Article 从文章页面提取出的链接预览元数据与正文纯文本
+type Article struct {
+	// Title 优先取 og:title / twitter:title，其次 <title>、<h1>
+	Title string
+	// Description 取 og:description / twitter:description / meta description
+	Description string
+	// Image 取 og:image / twitter:image，已解析为绝对地址
+	Image    string
+	SiteName string
+	// CanonicalURL 取 <link rel="canonical">，其次 og:url
+	CanonicalURL string
+	Text         string
+}
+
+// FetchArticle 抓取链接指向的页面（最多读取 maxBytes，<=0 时使用 ArticleMaxBodyBytes）并提取正文。
+// 非 HTML 响应返回 ErrNotHTML，4xx 状态码视为永久性错误；client 为空时只允许连接公网地址
+func FetchArticle(ctx context.Context, client *http.Client, rawURL string, maxBytes int64) (Article, error) {
+	if !isHTTPURL(rawURL) {
+		return Article{}, Permanent(fmt.Errorf("article: invalid url %q", rawURL))
+	}
+	if maxBytes <= 0 {
+		maxBytes = ArticleMaxBodyBytes
+	}
+	ctx, cancel := context.WithTimeout(ctx, articleRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return Article{}, Permanent(fmt.Errorf("article: build request: %w", err))
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.5")
+	resp, err := publicClientOrDefault(client).Do(req)
+	if err != nil {
+		return Article{}, fmt.Errorf("article: fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Article{}, statusError("article", resp.StatusCode)
+	}
+	if ct := strings.ToLower(resp.Header.Get("Content-Type")); ct != "" && !strings.Contains(ct, "html") {
+		return Article{}, Permanent(ErrNotHTML)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return Article{}, fmt.Errorf("article: read %s: %w", rawURL, err)
+	}
+	return ExtractArticle(body, resp.Request.URL)
+}
+
+// ExtractArticle 提取 OpenGraph / Twitter Card 元数据，并以简化的 readability 规则提取正文：
+// 去掉脚本、导航、页眉页脚等噪声后，按段落文字量给父节点（及祖父节点一半）打分，取得分最高的容器内的段落拼成正文；
+// 找不到合适段落时退回整个 body 的文本。base 用于解析相对的图片与 canonical 链接，可为 nil
+func ExtractArticle(body []byte, base *url.URL) (Article, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return Article{}, Permanent(fmt.Errorf("article: parse html: %w", err))
+	}
+	meta := func(keys ...string) string {
+		for _, k := range keys {
+			sel := doc.Find(`meta[property="` + k + `"], meta[name="` + k + `"]`).First()
+			if v, _ := sel.Attr("content"); collapseSpace(v) != "" {
+				return collapseSpace(v)
+			}
+		}
+		return ""
+	}
+	canonical, _ := doc.Find(`link[rel="canonical"]`).First().Attr("href")
+	a := Article{
+		Title:        FirstNonEmpty(meta("og:title", "twitter:title"), collapseSpace(doc.Find("title").First().Text()), collapseSpace(doc.Find("h1").First().Text())),
+		Description:  meta("og:description", "twitter:description", "description"),
+		Image:        resolveFeedLink(base, meta("og:image", "og:image:url", "twitter:image", "twitter:image:src")),
+		SiteName:     meta("og:site_name", "application-name"),
+		CanonicalURL: FirstNonEmpty(resolveFeedLink(base, canonical), resolveFeedLink(base, meta("og:url"))),
+	}
+
+	doc.Find("script, style, noscript, template, svg, iframe, form, nav, header, footer, aside, " +
+		"[role=navigation], [role=banner], [role=contentinfo], [aria-hidden=true]").Remove()
+
+	scores := make(map[*html.Node]int)
+	var best *html.Node
+	doc.Find("p, pre, blockquote").Each(func(_ int, p *goquery.Selection) {
+		n := utf8.RuneCountInString(collapseSpace(p.Text()))
+		if n < articleMinParagraphRunes {
+			return
+		}
+		parent := p.Parent()
+		if parent.Length() == 0 {
+			return
+		}
+		for i, node := range []*html.Node{parent.Get(0), parent.Parent().Get(0)} {
+			if node == nil {
+				continue
+			}
+			scores[node] += n >> i
+			if best == nil || scores[node] > scores[best] {
+				best = node
+			}
+		}
+	})
+
+	var parts []string
+	if best != nil {
+		goquery.NewDocumentFromNode(best).Find("h2, h3, p, pre, blockquote, li").Each(func(_ int, s *goquery.Selection) {
+			// 嵌套在引用或列表项中的段落由外层元素统一输出
+			if s.ParentsFiltered("blockquote, li, pre").Length() > 0 {
+				return
+			}
+			if t := collapseSpace(s.Text()); t != "" {
+				parts = append(parts, t)
+			}
+		})
+	}
+	if len(parts) == 0 {
+		if t := collapseSpace(doc.Find("body").Text()); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	a.Text = truncateFeedRunes(strings.Join(parts, "\n\n"), articleMaxTextRunes)
+	return a, nil
+}
+
+// collapseSpace 合并连续空白为单个空格
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
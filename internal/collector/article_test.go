@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchArticleFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{"/blog/rust-1.80": "article.html"})
+
+	a, err := FetchArticle(context.Background(), srv.Client(), srv.URL+"/blog/rust-1.80", 0)
+	if err != nil {
+		t.Fatalf("FetchArticle: %v", err)
+	}
+	wantMeta := Article{
+		Title:        "Announcing Rust 1.80.0",
+		Description:  "Rust 1.80 stabilizes LazyCell and LazyLock.",
+		Image:        srv.URL + "/images/rust-social.png",
+		SiteName:     "Rust Blog",
+		CanonicalURL: srv.URL + "/2024/07/25/Rust-1.80.0.html",
+	}
+	gotMeta := a
+	gotMeta.Text = ""
+	if gotMeta != wantMeta {
+		t.Errorf("meta = %+v\nwant %+v", gotMeta, wantMeta)
+	}
+	want := strings.Join([]string{
+		"By the Rust team",
+		"The Rust team is happy to announce a new version of Rust, 1.80.0. Rust is a programming language empowering everyone to build reliable and efficient software.",
+		"LazyCell and LazyLock",
+		"These new types delay the initialization of their data until first access. They are similar to the OnceCell and OnceLock types stabilized in 1.70, but with the initialization function included in the cell.",
+		"Lazy statics no longer need an external crate for the common cases.",
+		"Checked cfg names and values",
+		"Exclusive ranges in patterns",
+	}, "\n\n")
+	if a.Text != want {
+		t.Errorf("text =\n%s\nwant\n%s", a.Text, want)
+	}
+	for _, noise := range []string{"newsletter", "Copyright", "analytics", "About this website"} {
+		if strings.Contains(a.Text, noise) {
+			t.Errorf("text contains boilerplate %q", noise)
+		}
+	}
+}
+
+func TestFetchArticleRejects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/paper.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.7"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	_, err := FetchArticle(context.Background(), srv.Client(), srv.URL+"/paper.pdf", 0)
+	if !errors.Is(err, ErrNotHTML) || !IsPermanent(err) {
+		t.Errorf("pdf: err = %v, want permanent ErrNotHTML", err)
+	}
+	if _, err := FetchArticle(context.Background(), srv.Client(), srv.URL+"/missing", 0); !IsPermanent(err) {
+		t.Errorf("404: err = %v, want permanent", err)
+	}
+	if _, err := FetchArticle(context.Background(), srv.Client(), "javascript:alert(1)", 0); !IsPermanent(err) {
+		t.Errorf("bad scheme: err = %v, want permanent", err)
+	}
+}
+
+func TestExtractArticleFallsBackToBody(t *testing.T) {
+	a, err := ExtractArticle([]byte(`<html><head><title> 短讯 </title></head><body><div>短讯：<b>一句话</b>新闻</div><script>x()</script></body></html>`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Text != "短讯：一句话新闻" || a.Title != "短讯" || a.Image != "" || a.CanonicalURL != "" {
+		t.Errorf("article = %+v", a)
+	}
+}
@@ -0,0 +1,256 @@
+package collector
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	arxivBaseURL          = "https://export.arxiv.org"
+	arxivQueryPath        = "/api/query"
+	arxivMaxResults       = 100
+	arxivMaxResponseBytes = 8 << 20 // 8MB
+	arxivRequestTimeout   = 30 * time.Second
+	// arxivAbsURL 论文摘要页，arxiv 与热门论文渠道统一使用不带版本号的地址，便于去重
+	arxivAbsURL = "https://arxiv.org/abs/"
+	arxivPDFURL = "https://arxiv.org/pdf/"
+)
+
+// ArxivDefaultCategories 未配置 categories 时抓取的分类
+var ArxivDefaultCategories = []string{"cs.AI", "cs.CL", "cs.DC"}
+
+// arxivCategoryRe arXiv 分类形如 cs.AI、stat.ML、hep-th、astro-ph.CO
+var arxivCategoryRe = regexp.MustCompile(`^[A-Za-z][A-Za-z-]*(\.[A-Za-z-]+)?$`)
+
+// arxivIDRe 新式（2510.01234）与旧式（hep-th/9901001）arXiv 编号，可带版本号
+var arxivIDRe = regexp.MustCompile(`^([a-z-]+(\.[A-Z]{2})?/\d{7}|\d{4}\.\d{4,5})(v\d+)?$`)
+
+// ArxivCategoryKey 返回分类的小写形式，用于 category 过滤（分类大小写不敏感）；非法分类返回 false
+func ArxivCategoryKey(category string) (string, bool) {
+	category = strings.TrimSpace(category)
+	if len(category) > 32 || !arxivCategoryRe.MatchString(category) {
+		return "", false
+	}
+	return strings.ToLower(category), true
+}
+
+// ArxivFetcher 通过 arXiv Atom 导出 API 抓取若干分类下最新提交的论文。
+// 标题保持原文，摘要翻译为中文作为 Description；作者、分类与原文摘要记录在 RawData 中
+type ArxivFetcher struct {
+	// Categories 分类列表，为空时使用 ArxivDefaultCategories
+	Categories []string
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 export.arxiv.org 地址，默认 arxivBaseURL
+	BaseURL string
+}
+
+func (a *ArxivFetcher) Name() string {
+	return "arxiv"
+}
+
+// categories 返回去重、校验后的分类；非法分类会被忽略
+func (a *ArxivFetcher) categories() []string {
+	seen := make(map[string]bool, len(a.Categories))
+	var out []string
+	for _, c := range a.Categories {
+		key, ok := ArxivCategoryKey(c)
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, strings.TrimSpace(c))
+	}
+	if len(out) == 0 {
+		return ArxivDefaultCategories
+	}
+	return out
+}
+
+func (a *ArxivFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	cats := a.categories()
+	log.Printf("fetch arXiv %v...", cats)
+
+	terms := make([]string, len(cats))
+	for i, c := range cats {
+		terms[i] = "cat:" + c
+	}
+	query := url.Values{}
+	query.Set("search_query", strings.Join(terms, " OR "))
+	query.Set("sortBy", "submittedDate")
+	query.Set("sortOrder", "descending")
+	query.Set("start", "0")
+	query.Set("max_results", strconv.Itoa(arxivMaxResults))
+	papers, err := fetchArxivPapers(ctx, a.Client, a.BaseURL, query)
+	if err != nil {
+		return nil, err
+	}
+
+	abstracts := make([]string, len(papers))
+	for i, p := range papers {
+		abstracts[i] = p.abstract
+	}
+	translated := translateAllToChinese(ctx, a.Client, abstracts)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(papers))
+	for i, p := range papers {
+		published := p.published
+		if published.IsZero() {
+			published = now
+		}
+		raw := p.rawData()
+		raw["rank"] = i + 1
+		results = append(results, NewsItem{
+			Title:               p.title,
+			URL:                 arxivAbsURL + p.id,
+			Source:              "arxiv",
+			Description:         translated[i],
+			OriginalDescription: untranslated(p.abstract, translated[i]),
+			PublishedAt:         published,
+			HotScore:            float64(len(papers) - i),
+			RawData:             raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Println("arxiv: no items fetched")
+	}
+	return results, nil
+}
+
+// arxivPaper arXiv Atom 条目解析后的论文信息，id 不含版本号
+type arxivPaper struct {
+	id              string
+	version         string
+	title           string
+	abstract        string
+	authors         []string
+	primaryCategory string
+	categories      []string
+	comment         string
+	published       time.Time
+}
+
+// rawData 返回论文的公共元数据；categoryKeys 为小写分类，供 category 过滤使用
+func (p arxivPaper) rawData() map[string]any {
+	keys := make([]string, len(p.categories))
+	for i, c := range p.categories {
+		keys[i] = strings.ToLower(c)
+	}
+	raw := map[string]any{
+		"arxivId":         p.id,
+		"authors":         p.authors,
+		"categories":      p.categories,
+		"categoryKeys":    keys,
+		"primaryCategory": p.primaryCategory,
+		"abstract":        p.abstract,
+		"pdfUrl":          arxivPDFURL + p.id,
+	}
+	if p.version != "" {
+		raw["version"] = p.version
+	}
+	if p.comment != "" {
+		raw["comment"] = p.comment
+	}
+	return raw
+}
+
+type arxivFeed struct {
+	Entries []struct {
+		ID        string `xml:"id"`
+		Title     string `xml:"title"`
+		Summary   string `xml:"summary"`
+		Published string `xml:"published"`
+		Authors   []struct {
+			Name string `xml:"name"`
+		} `xml:"author"`
+		Categories []struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+		PrimaryCategory struct {
+			Term string `xml:"term,attr"`
+		} `xml:"http://arxiv.org/schemas/atom primary_category"`
+		Comment string `xml:"http://arxiv.org/schemas/atom comment"`
+	} `xml:"entry"`
+}
+
+// fetchArxivPapers 调用 arXiv 查询接口（search_query 或 id_list），按返回顺序解析论文
+func fetchArxivPapers(ctx context.Context, client *http.Client, baseURL string, query url.Values) ([]arxivPaper, error) {
+	ctx, cancel := context.WithTimeout(ctx, arxivRequestTimeout)
+	defer cancel()
+	rawURL := baseURLOrDefault(baseURL, arxivBaseURL) + arxivQueryPath + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("arxiv: build request: %w", err))
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	resp, err := httpClientOrDefault(client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("arxiv: fetch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("arxiv", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, arxivMaxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("arxiv: read body: %w", err)
+	}
+	var feed arxivFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, Permanent(fmt.Errorf("arxiv: unmarshal: %w", err))
+	}
+
+	papers := make([]arxivPaper, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		id, version := splitArxivID(e.ID)
+		title := strings.Join(strings.Fields(e.Title), " ")
+		// 查询出错时 arXiv 返回 200 + 一条 id 为 api/errors 的条目，这里按编号格式过滤掉
+		if id == "" || title == "" {
+			continue
+		}
+		p := arxivPaper{
+			id:              id,
+			version:         version,
+			title:           title,
+			abstract:        strings.Join(strings.Fields(e.Summary), " "),
+			primaryCategory: e.PrimaryCategory.Term,
+			comment:         strings.Join(strings.Fields(e.Comment), " "),
+			published:       parseFeedTime(e.Published),
+		}
+		for _, au := range e.Authors {
+			if name := strings.TrimSpace(au.Name); name != "" {
+				p.authors = append(p.authors, name)
+			}
+		}
+		for _, c := range e.Categories {
+			if term := strings.TrimSpace(c.Term); term != "" {
+				p.categories = append(p.categories, term)
+			}
+		}
+		papers = append(papers, p)
+	}
+	return papers, nil
+}
+
+// splitArxivID 从摘要页地址或编号中取出不带版本号的 arXiv 编号与版本，如 http://arxiv.org/abs/2510.01234v2 → 2510.01234, v2
+func splitArxivID(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "/abs/"); i >= 0 {
+		s = s[i+len("/abs/"):]
+	}
+	m := arxivIDRe.FindStringSubmatch(s)
+	if m == nil {
+		return "", ""
+	}
+	return m[1], m[3]
+}
@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestArxivFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/api/query?search_query=cat:cs.AI OR cat:cs.CL&sortBy=submittedDate": "arxiv_query.xml",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &ArxivFetcher{Categories: []string{"cs.AI", "cs.ai", "cs.CL", "bad cat"}, Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "arxiv", srv, items)
+}
+
+func TestSplitArxivID(t *testing.T) {
+	cases := []struct{ in, id, version string }{
+		{"http://arxiv.org/abs/2510.01234v2", "2510.01234", "v2"},
+		{"2510.01234", "2510.01234", ""},
+		{"https://arxiv.org/abs/hep-th/9901001v1", "hep-th/9901001", "v1"},
+		{"http://arxiv.org/api/errors#incorrect_id_format", "", ""},
+	}
+	for _, c := range cases {
+		id, version := splitArxivID(c.in)
+		if id != c.id || version != c.version {
+			t.Errorf("splitArxivID(%q) = %q, %q; want %q, %q", c.in, id, version, c.id, c.version)
+		}
+	}
+	if key, ok := ArxivCategoryKey("astro-ph.CO"); !ok || key != "astro-ph.co" {
+		t.Errorf("ArxivCategoryKey(astro-ph.CO) = %q, %v", key, ok)
+	}
+	if _, ok := ArxivCategoryKey("cs.AI' OR 1=1"); ok {
+		t.Error("ArxivCategoryKey accepted invalid category")
+	}
+}
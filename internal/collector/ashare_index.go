@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -22,13 +23,21 @@ type AShareIndexFetcher struct {
 	// - 市场已收盘 && HasTodayData(now) == true  -> 直接跳过，不再访问行情源
 	// - 市场已收盘 && HasTodayData(now) == false -> 仍然允许执行一次 Fetch，用当前价回填当天数据
 	HasTodayData func(time.Time) bool
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖东方财富行情接口地址，默认 eastMoneyBaseURL
+	BaseURL string
 }
 
 func (a *AShareIndexFetcher) Name() string {
 	return "ashare_index"
 }
 
-const eastMoneyStockGetURL = "https://push2.eastmoney.com/api/qt/stock/get"
+const (
+	eastMoneyBaseURL        = "https://push2.eastmoney.com"
+	eastMoneyStockGetPath   = "/api/qt/stock/get"
+	eastMoneyRequestTimeout = 10 * time.Second
+)
 
 // 三大指数：上证 1.000001，深证成指 0.399001，创业板指 0.399006
 var indexSecIDs = []struct {
@@ -81,22 +90,22 @@ func isAshareTradingWeekday(t time.Time) bool {
 	}
 }
 
-func (a *AShareIndexFetcher) Fetch() ([]NewsItem, error) {
+func (a *AShareIndexFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
 	now := time.Now()
 	if !isAshareMarketOpen(now) {
 		// 收盘后 / 盘前：若注入了 HasTodayData，则仅在“今天尚无任何 A 股数据”时允许再拉一次，
 		// 用当前价作为当天快照；否则直接跳过，避免在休市期间持续访问行情源。
 		if a.HasTodayData == nil {
 			log.Println("skip A-share fetch: market closed")
-			return nil, nil
+			return nil, ErrSkipped
 		}
 		if !isAshareTradingWeekday(now) {
 			log.Println("skip A-share fetch: non-trading weekday (weekend)")
-			return nil, nil
+			return nil, ErrSkipped
 		}
 		if a.HasTodayData(now) {
 			log.Println("skip A-share fetch: market closed and DB already has data for today")
-			return nil, nil
+			return nil, ErrSkipped
 		}
 		log.Println("A-share market closed but DB has no data for today, fetch once to backfill snapshot...")
 	}
@@ -104,7 +113,7 @@ func (a *AShareIndexFetcher) Fetch() ([]NewsItem, error) {
 	log.Println("fetch A-share (East Money)...")
 
 	// 1. 三大指数置顶
-	results := a.fetchIndices()
+	results := a.fetchIndices(ctx)
 	// 2. 自选股：优先从 GetStockCodes（如 DB）取，否则从环境变量取；并行请求
 	var codes []string
 	if a.GetStockCodes != nil {
@@ -120,7 +129,7 @@ func (a *AShareIndexFetcher) Fetch() ([]NewsItem, error) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			item := a.fetchOneStock(code)
+			item := a.fetchOneStock(ctx, code)
 			if item != nil {
 				mu.Lock()
 				results = append(results, *item)
@@ -129,6 +138,9 @@ func (a *AShareIndexFetcher) Fetch() ([]NewsItem, error) {
 		}()
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -160,7 +172,7 @@ func codeToSecID(code string) string {
 	}
 }
 
-func (a *AShareIndexFetcher) fetchOneStock(code string) *NewsItem {
+func (a *AShareIndexFetcher) fetchOneStock(ctx context.Context, code string) *NewsItem {
 	if code == "" {
 		return nil
 	}
@@ -168,17 +180,18 @@ func (a *AShareIndexFetcher) fetchOneStock(code string) *NewsItem {
 	if secID == "" {
 		return nil
 	}
-	client := &http.Client{Timeout: 10 * time.Second}
+	ctx, cancel := context.WithTimeout(ctx, eastMoneyRequestTimeout)
+	defer cancel()
 	// f43: 最新价（分），f58: 名称，f60: 昨收（分），f170: 涨跌幅（百分比 * 100）
 	params := url.Values{"secid": {secID}, "fields": {"f43,f58,f60,f170"}}
-	u := eastMoneyStockGetURL + "?" + params.Encode()
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	u := baseURLOrDefault(a.BaseURL, eastMoneyBaseURL) + eastMoneyStockGetPath + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Referer", "https://quote.eastmoney.com/")
-	resp, err := client.Do(req)
+	resp, err := httpClientOrDefault(a.Client).Do(req)
 	if err != nil {
 		log.Printf("fetch A-share stock %s: %v", code, err)
 		return nil
@@ -229,7 +242,7 @@ func (a *AShareIndexFetcher) fetchOneStock(code string) *NewsItem {
 }
 
 // fetchIndices 拉取三大指数（东方财富 qt/stock/get），并行请求
-func (a *AShareIndexFetcher) fetchIndices() []NewsItem {
+func (a *AShareIndexFetcher) fetchIndices(ctx context.Context) []NewsItem {
 	now := time.Now()
 	results := make([]*NewsItem, len(indexSecIDs))
 	var wg sync.WaitGroup
@@ -240,7 +253,7 @@ func (a *AShareIndexFetcher) fetchIndices() []NewsItem {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			item := a.fetchOneIndex(secID, name, now)
+			item := a.fetchOneIndex(ctx, secID, name, now)
 			if item != nil {
 				results[i] = item
 			}
@@ -256,18 +269,19 @@ func (a *AShareIndexFetcher) fetchIndices() []NewsItem {
 	return out
 }
 
-func (a *AShareIndexFetcher) fetchOneIndex(secID, indexName string, now time.Time) *NewsItem {
-	client := &http.Client{Timeout: 10 * time.Second}
+func (a *AShareIndexFetcher) fetchOneIndex(ctx context.Context, secID, indexName string, now time.Time) *NewsItem {
+	ctx, cancel := context.WithTimeout(ctx, eastMoneyRequestTimeout)
+	defer cancel()
 	// f43: 最新点位（×100），f58: 名称，f60: 昨收（×100），f170: 涨跌幅（百分比 * 100）
 	params := url.Values{"secid": {secID}, "fields": {"f43,f58,f60,f170"}}
-	u := eastMoneyStockGetURL + "?" + params.Encode()
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	u := baseURLOrDefault(a.BaseURL, eastMoneyBaseURL) + eastMoneyStockGetPath + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Referer", "https://quote.eastmoney.com/")
-	resp, err := client.Do(req)
+	resp, err := httpClientOrDefault(a.Client).Do(req)
 	if err != nil {
 		log.Printf("fetch index %s: %v", indexName, err)
 		return nil
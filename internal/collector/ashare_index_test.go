@@ -1,6 +1,8 @@
 package collector
 
 import (
+	"context"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -102,3 +104,35 @@ func TestCodeToSecID(t *testing.T) {
 	}
 }
 
+func TestAShareFetchIndicesAndStockFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/api/qt/stock/get":                "eastmoney_empty.json",
+		"/api/qt/stock/get?secid=1.000001": "eastmoney_1.000001.json",
+		"/api/qt/stock/get?secid=0.399001": "eastmoney_0.399001.json",
+		"/api/qt/stock/get?secid=0.399006": "eastmoney_0.399006.json",
+		"/api/qt/stock/get?secid=1.600519": "eastmoney_1.600519.json",
+	})
+	a := &AShareIndexFetcher{Client: srv.Client(), BaseURL: srv.URL}
+	ctx := context.Background()
+
+	items := a.fetchIndices(ctx)
+	if stock := a.fetchOneStock(ctx, "600519"); stock != nil {
+		items = append(items, *stock)
+	}
+	// 无行情数据的代码应被忽略
+	if stock := a.fetchOneStock(ctx, "000000"); stock != nil {
+		t.Fatalf("expected nil for stock without data, got %+v", stock)
+	}
+
+	// URL 中带有采集时刻的 ?t= 时间戳，比对前去掉
+	for i := range items {
+		if u, err := url.Parse(items[i].URL); err == nil {
+			if u.Query().Get("t") == "" {
+				t.Fatalf("item %q URL missing ?t= timestamp: %s", items[i].Title, items[i].URL)
+			}
+			u.RawQuery = ""
+			items[i].URL = u.String()
+		}
+	}
+	assertGolden(t, "ashare", srv, items)
+}
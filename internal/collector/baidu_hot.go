@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -10,14 +11,24 @@ import (
 	"time"
 )
 
-const baiduMaxResponseBytes = 2 << 20 // 2MB
+const (
+	baiduBaseURL          = "https://top.baidu.com"
+	baiduBoardPath        = "/board?tab=realtime"
+	baiduMaxResponseBytes = 2 << 20 // 2MB
+	baiduRequestTimeout   = 10 * time.Second
+)
 
 var baiduSDataRe = regexp.MustCompile(`(?s)<!--s-data:(.*?)-->`)
 
 // BaiduHotFetcher 抓取百度实时热搜榜。
 // 实现方式与 ourongxing/newsnow 一致：从 HTML 中提取 <!--s-data:...--> 内嵌 JSON，
 // 只使用其中的 word/rawUrl/desc，省去所有详情页与浏览器采集逻辑。
-type BaiduHotFetcher struct{}
+type BaiduHotFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 top.baidu.com 站点地址，默认 baiduBaseURL
+	BaseURL string
+}
 
 func (b *BaiduHotFetcher) Name() string {
 	return "baidu_hot"
@@ -37,11 +48,17 @@ type baiduState struct {
 	} `json:"data"`
 }
 
-func (b *BaiduHotFetcher) Fetch() ([]NewsItem, error) {
+func (b *BaiduHotFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
 	log.Println("fetch Baidu Hot Search...")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get("https://top.baidu.com/board?tab=realtime")
+	ctx, cancel := context.WithTimeout(ctx, baiduRequestTimeout)
+	defer cancel()
+	boardURL := baseURLOrDefault(b.BaseURL, baiduBaseURL) + baiduBoardPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, boardURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClientOrDefault(b.Client).Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +73,11 @@ func (b *BaiduHotFetcher) Fetch() ([]NewsItem, error) {
 	if err != nil {
 		return nil, err
 	}
-	html := string(body)
+	return parseBaiduBoard(string(body))
+}
 
+// parseBaiduBoard 从热搜榜 HTML 中解析内嵌的 s-data JSON
+func parseBaiduBoard(html string) ([]NewsItem, error) {
 	matches := baiduSDataRe.FindStringSubmatch(html)
 	if len(matches) < 2 {
 		log.Printf("baidu_hot: failed to extract s-data JSON")
@@ -67,7 +87,7 @@ func (b *BaiduHotFetcher) Fetch() ([]NewsItem, error) {
 	var state baiduState
 	if err := json.Unmarshal([]byte(matches[1]), &state); err != nil {
 		log.Printf("baidu_hot: unmarshal s-data JSON error: %v", err)
-		return nil, err
+		return nil, Permanent(err)
 	}
 
 	if len(state.Data.Cards) == 0 || len(state.Data.Cards[0].Content) == 0 {
@@ -89,7 +109,7 @@ func (b *BaiduHotFetcher) Fetch() ([]NewsItem, error) {
 
 		url := strings.TrimSpace(c.RawURL)
 		if url == "" {
-			url = "https://top.baidu.com/board?tab=realtime"
+			url = baiduBaseURL + baiduBoardPath
 		}
 
 		desc := strings.TrimSpace(c.Desc)
@@ -0,0 +1,34 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBaiduHotFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/board?tab=realtime": "baidu_board.html",
+	})
+	f := &BaiduHotFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	for _, it := range items {
+		if it.PublishedAt.IsZero() {
+			t.Fatalf("item %q has zero PublishedAt", it.Title)
+		}
+	}
+	assertGolden(t, "baidu_hot", srv, items)
+}
+
+func TestParseBaiduBoardWithoutSData(t *testing.T) {
+	items, err := parseBaiduBoard("<html><body>no data</body></html>")
+	if err != nil {
+		t.Fatalf("parseBaiduBoard error: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected no items, got %d", len(items))
+	}
+}
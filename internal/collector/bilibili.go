@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	bilibiliBaseURL          = "https://api.bilibili.com"
+	bilibiliPopularPath      = "/x/web-interface/popular?ps=50&pn=1"
+	bilibiliMaxResponseBytes = 4 << 20 // 4MB
+	bilibiliRequestTimeout   = 10 * time.Second
+)
+
+// BilibiliPopularFetcher 抓取哔哩哔哩综合热门视频。播放量作为热度值记录在 RawData.heat 中
+type BilibiliPopularFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 api.bilibili.com 地址，默认 bilibiliBaseURL
+	BaseURL string
+}
+
+func (b *BilibiliPopularFetcher) Name() string {
+	return "bilibili_popular"
+}
+
+type bilibiliPopularResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		List []struct {
+			BVID    string `json:"bvid"`
+			Title   string `json:"title"`
+			Desc    string `json:"desc"`
+			Pic     string `json:"pic"`
+			TName   string `json:"tname"`
+			PubDate int64  `json:"pubdate"`
+			Owner   struct {
+				Name string `json:"name"`
+			} `json:"owner"`
+			Stat struct {
+				View    int64 `json:"view"`
+				Like    int64 `json:"like"`
+				Danmaku int64 `json:"danmaku"`
+				Reply   int64 `json:"reply"`
+			} `json:"stat"`
+			RcmdReason struct {
+				Content string `json:"content"`
+			} `json:"rcmd_reason"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+func (b *BilibiliPopularFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Bilibili Popular...")
+
+	ctx, cancel := context.WithTimeout(ctx, bilibiliRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	header.Set("Referer", "https://www.bilibili.com/")
+	var resp bilibiliPopularResponse
+	if err := getJSON(ctx, b.Client, "bilibili_popular", baseURLOrDefault(b.BaseURL, bilibiliBaseURL)+bilibiliPopularPath, header, bilibiliMaxResponseBytes, &resp); err != nil {
+		return nil, err
+	}
+	// 非 0 通常是风控（如 -352），下次执行可能恢复，按可重试错误处理
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("bilibili_popular: code %d: %s", resp.Code, resp.Message)
+	}
+
+	list := resp.Data.List
+	now := time.Now()
+	results := make([]NewsItem, 0, len(list))
+	for idx, it := range list {
+		title := strings.TrimSpace(it.Title)
+		if title == "" || it.BVID == "" {
+			continue
+		}
+		desc := strings.TrimSpace(it.Desc)
+		if desc == "" || desc == "-" {
+			desc = title
+		}
+		raw := map[string]any{
+			"rank":    idx + 1,
+			"heat":    it.Stat.View,
+			"author":  it.Owner.Name,
+			"likes":   it.Stat.Like,
+			"danmaku": it.Stat.Danmaku,
+			"replies": it.Stat.Reply,
+			"cover":   it.Pic,
+		}
+		// 视频的投稿时间可能早于上榜数日，只记录在 RawData 中；发布时间与其它热榜一致，取上榜（采集）时间
+		if it.PubDate > 0 {
+			raw["pubDate"] = time.Unix(it.PubDate, 0).UTC().Format(time.RFC3339)
+		}
+		if it.TName != "" {
+			raw["category"] = it.TName
+		}
+		if it.RcmdReason.Content != "" {
+			raw["reason"] = it.RcmdReason.Content
+		}
+		results = append(results, NewsItem{
+			Title:       title,
+			URL:         "https://www.bilibili.com/video/" + it.BVID,
+			Source:      "bilibili",
+			Description: desc,
+			PublishedAt: now,
+			HotScore:    float64(len(list) - idx),
+			RawData:     raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Printf("bilibili_popular: no items parsed")
+	}
+	return results, nil
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBilibiliPopularFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/x/web-interface/popular": "bilibili_popular.json",
+	})
+	f := &BilibiliPopularFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "bilibili_popular", srv, items)
+	// 发布时间为上榜（采集）时间，而不是内容本身的创建时间
+	for _, it := range items {
+		if time.Since(it.PublishedAt) > time.Minute {
+			t.Fatalf("PublishedAt = %v, want the fetch time", it.PublishedAt)
+		}
+	}
+}
+
+func TestBilibiliPopularFetcherRiskControl(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/x/web-interface/popular": "bilibili_risk.json",
+	})
+	f := &BilibiliPopularFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	_, err := f.FetchContext(context.Background())
+	if err == nil {
+		t.Fatalf("expected error for non-zero code")
+	}
+	if IsPermanent(err) {
+		t.Fatalf("risk control error should be retryable, got permanent: %v", err)
+	}
+}
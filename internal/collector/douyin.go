@@ -0,0 +1,117 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	douyinBaseURL          = "https://www.douyin.com"
+	douyinHotPath          = "/aweme/v1/web/hot/search/list/?device_platform=webapp&aid=6383&channel=channel_pc_web&detail_list=1"
+	douyinCookieURL        = "https://login.douyin.com/"
+	douyinMaxResponseBytes = 4 << 20 // 4MB
+	douyinRequestTimeout   = 10 * time.Second
+)
+
+// DouyinHotFetcher 抓取抖音热榜。接口要求携带 ttwid 等 Cookie，先访问登录页拿到 Cookie 再请求榜单（与 newsnow 做法一致）；
+// 热度值（hot_value）记录在 RawData.heat 中
+type DouyinHotFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 www.douyin.com 站点地址，默认 douyinBaseURL
+	BaseURL string
+	// CookieURL 可选，覆盖获取 Cookie 的地址，默认 douyinCookieURL
+	CookieURL string
+}
+
+func (d *DouyinHotFetcher) Name() string {
+	return "douyin_hot"
+}
+
+type douyinHotResponse struct {
+	Data struct {
+		WordList []struct {
+			Word       string  `json:"word"`
+			HotValue   float64 `json:"hot_value"`
+			SentenceID string  `json:"sentence_id"`
+			EventTime  int64   `json:"event_time"`
+			Label      int     `json:"label"`
+		} `json:"word_list"`
+	} `json:"data"`
+}
+
+func (d *DouyinHotFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Douyin Hot...")
+
+	ctx, cancel := context.WithTimeout(ctx, douyinRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	header.Set("Referer", "https://www.douyin.com/")
+	if cookie := d.fetchCookie(ctx); cookie != "" {
+		header.Set("Cookie", cookie)
+	}
+	var resp douyinHotResponse
+	if err := getJSON(ctx, d.Client, "douyin_hot", baseURLOrDefault(d.BaseURL, douyinBaseURL)+douyinHotPath, header, douyinMaxResponseBytes, &resp); err != nil {
+		return nil, err
+	}
+
+	list := resp.Data.WordList
+	now := time.Now()
+	results := make([]NewsItem, 0, len(list))
+	for idx, it := range list {
+		title := strings.TrimSpace(it.Word)
+		if title == "" {
+			continue
+		}
+		link := "https://www.douyin.com/search/" + url.PathEscape(title)
+		if it.SentenceID != "" {
+			link = "https://www.douyin.com/hot/" + url.PathEscape(it.SentenceID)
+		}
+		raw := map[string]any{
+			"rank": idx + 1,
+			"heat": it.HotValue,
+		}
+		// 事件时间可能早于上榜数日，只记录在 RawData 中；发布时间与其它热榜一致，取上榜（采集）时间
+		if it.EventTime > 0 {
+			raw["eventTime"] = time.Unix(it.EventTime, 0).UTC().Format(time.RFC3339)
+		}
+		results = append(results, NewsItem{
+			Title:       title,
+			URL:         link,
+			Source:      "douyin",
+			Description: title,
+			PublishedAt: now,
+			HotScore:    float64(len(list) - idx),
+			RawData:     raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Printf("douyin_hot: no items parsed")
+	}
+	return results, nil
+}
+
+// fetchCookie 访问登录页获取匿名 Cookie；失败时返回空串，仍尝试直接请求榜单
+func (d *DouyinHotFetcher) fetchCookie(ctx context.Context) string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURLOrDefault(d.CookieURL, douyinCookieURL), nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("User-Agent", browserUserAgent)
+	resp, err := httpClientOrDefault(d.Client).Do(req)
+	if err != nil {
+		log.Printf("douyin_hot: fetch cookie: %v", err)
+		return ""
+	}
+	resp.Body.Close()
+	parts := make([]string, 0, len(resp.Cookies()))
+	for _, c := range resp.Cookies() {
+		parts = append(parts, c.Name+"="+c.Value)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDouyinHotFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/aweme/v1/web/hot/search/list/": "douyin_hot.json",
+	})
+	var gotCookie string
+	cookieSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "ttwid", Value: "abc"})
+	}))
+	t.Cleanup(cookieSrv.Close)
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/aweme/v1/web/hot/search/list/" {
+			gotCookie = r.Header.Get("Cookie")
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	f := &DouyinHotFetcher{Client: client, BaseURL: srv.URL, CookieURL: cookieSrv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	if gotCookie != "ttwid=abc" {
+		t.Fatalf("expected cookie from login page to be forwarded, got %q", gotCookie)
+	}
+	assertGolden(t, "douyin_hot", srv, items)
+	// 发布时间为上榜（采集）时间，而不是内容本身的创建时间
+	for _, it := range items {
+		if time.Since(it.PublishedAt) > time.Minute {
+			t.Fatalf("PublishedAt = %v, want the fetch time", it.PublishedAt)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,485 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	feedMaxItems        = 50
+	feedMaxBodyBytes    = 5 << 20 // 5MB
+	feedRequestTimeout  = 15 * time.Second
+	feedSummaryMaxRunes = 600
+)
+
+// FeedFetcher 通用订阅源采集器，支持 RSS 2.0、Atom 与 JSON Feed，由渠道配置实例化，无需为每个博客 / 发布页单独写采集器。
+// 多个 URL 的条目按链接合并，条目的 Source 为渠道 code。
+type FeedFetcher struct {
+	// Source 渠道 code，同时用作条目的 Source 与采集器名称后缀
+	Source string
+	// URLs 订阅地址列表
+	URLs []string
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用只允许连接公网地址的共享客户端
+	Client *http.Client
+}
+
+func (f *FeedFetcher) Name() string {
+	return "feed_" + f.Source
+}
+
+// feedEntry 三种格式解析后的统一条目
+type feedEntry struct {
+	title      string
+	link       string
+	summary    string
+	author     string
+	guid       string
+	published  time.Time
+	categories []string
+	enclosure  map[string]any
+}
+
+func (f *FeedFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	if f.Source == "" || len(f.URLs) == 0 {
+		return nil, Permanent(errors.New("feed: source and urls are required"))
+	}
+	log.Printf("fetch feed %s (%d urls)...", f.Source, len(f.URLs))
+
+	var (
+		results []NewsItem
+		seen    = make(map[string]bool)
+		lastErr error
+		now     = time.Now()
+	)
+	for _, feedURL := range f.URLs {
+		feedTitle, entries, err := f.fetchFeed(ctx, feedURL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("feed: %w", ctx.Err())
+			}
+			log.Printf("feed %s: %v", f.Source, err)
+			lastErr = err
+			continue
+		}
+		if len(entries) > feedMaxItems {
+			entries = entries[:feedMaxItems]
+		}
+		for i, e := range entries {
+			if e.title == "" || seen[e.link] {
+				continue
+			}
+			seen[e.link] = true
+			published := e.published
+			if published.IsZero() {
+				published = now
+			}
+			raw := map[string]any{
+				"feedTitle": feedTitle,
+				"feedUrl":   feedURL,
+				"rank":      i + 1,
+			}
+			if e.author != "" {
+				raw["author"] = e.author
+			}
+			if e.guid != "" {
+				raw["guid"] = e.guid
+			}
+			if len(e.categories) > 0 {
+				raw["categories"] = e.categories
+			}
+			if e.enclosure != nil {
+				raw["enclosure"] = e.enclosure
+			}
+			results = append(results, NewsItem{
+				Title:       e.title,
+				URL:         e.link,
+				Source:      f.Source,
+				Description: e.summary,
+				PublishedAt: published,
+				HotScore:    float64(feedMaxItems - i),
+				RawData:     raw,
+			})
+		}
+	}
+	if len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// fetchFeed 下载并解析单个订阅地址
+func (f *FeedFetcher) fetchFeed(ctx context.Context, feedURL string) (string, []feedEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, feedRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return "", nil, Permanent(fmt.Errorf("feed: build request: %w", err))
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, */*;q=0.8")
+	resp, err := publicClientOrDefault(f.Client).Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("feed: fetch %s: %w", feedURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, statusError("feed", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, feedMaxBodyBytes))
+	if err != nil {
+		return "", nil, fmt.Errorf("feed: read %s: %w", feedURL, err)
+	}
+	base, _ := url.Parse(feedURL)
+	title, entries, err := parseFeed(body, base)
+	if err != nil {
+		return "", nil, Permanent(fmt.Errorf("feed: parse %s: %w", feedURL, err))
+	}
+	return title, entries, nil
+}
+
+// parseFeed 根据内容判断格式：以 { 开头为 JSON Feed，否则按 XML 根元素区分 RSS（rss / RDF）与 Atom（feed）。
+// 相对链接基于 base 解析，没有 http(s) 链接的条目会被丢弃
+func parseFeed(body []byte, base *url.URL) (string, []feedEntry, error) {
+	body = bytes.TrimPrefix(bytes.TrimSpace(body), []byte("\xef\xbb\xbf"))
+	if len(body) > 0 && body[0] == '{' {
+		return parseJSONFeed(body, base)
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// 非 UTF-8 声明的订阅按原样读取，非法字节由存储层统一替换
+		return input, nil
+	}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", nil, fmt.Errorf("unrecognized feed format: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rss", "RDF":
+			var doc rssDoc
+			if err := dec.DecodeElement(&doc, &start); err != nil {
+				return "", nil, err
+			}
+			return doc.entries(base)
+		case "feed":
+			var doc atomFeed
+			if err := dec.DecodeElement(&doc, &start); err != nil {
+				return "", nil, err
+			}
+			return doc.entries(base)
+		default:
+			return "", nil, fmt.Errorf("unrecognized feed root <%s>", start.Name.Local)
+		}
+	}
+}
+
+type rssDoc struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	// RSS 1.0 (RDF) 的 item 与 channel 同级
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Links       []string `xml:"link"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string   `xml:"pubDate"`
+	DCDate      string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	GUID        string   `xml:"guid"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	Enclosure   *struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length int64  `xml:"length,attr"`
+	} `xml:"enclosure"`
+}
+
+func (d *rssDoc) entries(base *url.URL) (string, []feedEntry, error) {
+	items := append(d.Channel.Items, d.Items...)
+	out := make([]feedEntry, 0, len(items))
+	for _, it := range items {
+		link := FirstNonEmpty(it.Links...)
+		if link == "" && isHTTPURL(it.GUID) {
+			link = it.GUID
+		}
+		e := feedEntry{
+			title:      htmlToText(it.Title),
+			link:       resolveFeedLink(base, link),
+			summary:    feedSummary(FirstNonEmpty(it.Description, it.Content)),
+			author:     strings.TrimSpace(FirstNonEmpty(it.Author, it.Creator)),
+			guid:       strings.TrimSpace(it.GUID),
+			published:  parseFeedTime(FirstNonEmpty(it.PubDate, it.DCDate)),
+			categories: trimStrings(it.Categories),
+		}
+		if it.Enclosure != nil && it.Enclosure.URL != "" {
+			e.enclosure = feedEnclosure(base, it.Enclosure.URL, it.Enclosure.Type, it.Enclosure.Length)
+		}
+		if e.link != "" {
+			out = append(out, e)
+		}
+	}
+	return htmlToText(d.Channel.Title), out, nil
+}
+
+type atomFeed struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	ID        string     `xml:"id"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Authors   []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+}
+
+func (d *atomFeed) entries(base *url.URL) (string, []feedEntry, error) {
+	out := make([]feedEntry, 0, len(d.Entries))
+	for _, it := range d.Entries {
+		e := feedEntry{
+			title:     htmlToText(it.Title),
+			summary:   feedSummary(FirstNonEmpty(it.Summary, it.Content)),
+			guid:      strings.TrimSpace(it.ID),
+			published: parseFeedTime(FirstNonEmpty(it.Published, it.Updated)),
+		}
+		for _, l := range it.Links {
+			switch l.Rel {
+			case "", "alternate":
+				if e.link == "" {
+					e.link = resolveFeedLink(base, l.Href)
+				}
+			case "enclosure":
+				if e.enclosure == nil && l.Href != "" {
+					e.enclosure = feedEnclosure(base, l.Href, l.Type, l.Length)
+				}
+			}
+		}
+		if e.link == "" && isHTTPURL(e.guid) {
+			e.link = e.guid
+		}
+		if len(it.Authors) > 0 {
+			e.author = strings.TrimSpace(it.Authors[0].Name)
+		}
+		for _, c := range it.Categories {
+			if t := strings.TrimSpace(c.Term); t != "" {
+				e.categories = append(e.categories, t)
+			}
+		}
+		if e.link != "" {
+			out = append(out, e)
+		}
+	}
+	return htmlToText(d.Title), out, nil
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeed struct {
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	Items   []struct {
+		ID            any              `json:"id"`
+		URL           string           `json:"url"`
+		ExternalURL   string           `json:"external_url"`
+		Title         string           `json:"title"`
+		Summary       string           `json:"summary"`
+		ContentText   string           `json:"content_text"`
+		ContentHTML   string           `json:"content_html"`
+		DatePublished string           `json:"date_published"`
+		DateModified  string           `json:"date_modified"`
+		Author        *jsonFeedAuthor  `json:"author"` // JSON Feed 1.0
+		Authors       []jsonFeedAuthor `json:"authors"`
+		Tags          []string         `json:"tags"`
+		Attachments   []struct {
+			URL         string `json:"url"`
+			MimeType    string `json:"mime_type"`
+			SizeInBytes int64  `json:"size_in_bytes"`
+		} `json:"attachments"`
+	} `json:"items"`
+}
+
+func parseJSONFeed(body []byte, base *url.URL) (string, []feedEntry, error) {
+	var doc jsonFeed
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", nil, err
+	}
+	if !strings.HasPrefix(doc.Version, "https://jsonfeed.org/version/") {
+		return "", nil, fmt.Errorf("unrecognized json feed version %q", doc.Version)
+	}
+	out := make([]feedEntry, 0, len(doc.Items))
+	for _, it := range doc.Items {
+		e := feedEntry{
+			title:      htmlToText(it.Title),
+			link:       resolveFeedLink(base, FirstNonEmpty(it.URL, it.ExternalURL)),
+			summary:    feedSummary(FirstNonEmpty(it.Summary, it.ContentText, it.ContentHTML)),
+			published:  parseFeedTime(FirstNonEmpty(it.DatePublished, it.DateModified)),
+			categories: trimStrings(it.Tags),
+		}
+		if it.ID != nil {
+			e.guid = strings.TrimSpace(fmt.Sprint(it.ID))
+		}
+		if len(it.Authors) > 0 {
+			e.author = strings.TrimSpace(it.Authors[0].Name)
+		} else if it.Author != nil {
+			e.author = strings.TrimSpace(it.Author.Name)
+		}
+		if len(it.Attachments) > 0 && it.Attachments[0].URL != "" {
+			a := it.Attachments[0]
+			e.enclosure = feedEnclosure(base, a.URL, a.MimeType, a.SizeInBytes)
+		}
+		if e.title == "" {
+			// JSON Feed 允许无标题的条目（如微博客），用正文开头代替
+			e.title = truncateFeedRunes(e.summary, 80)
+		}
+		if e.link != "" {
+			out = append(out, e)
+		}
+	}
+	return doc.Title, out, nil
+}
+
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339Nano,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseFeedTime 解析订阅中常见的日期格式，无法解析时返回零值（由调用方回退为采集时间）
+func parseFeedTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// resolveFeedLink 将相对链接解析为绝对地址，只接受 http(s)
+func resolveFeedLink(base *url.URL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+func feedEnclosure(base *url.URL, link, typ string, length int64) map[string]any {
+	link = resolveFeedLink(base, link)
+	if link == "" {
+		return nil
+	}
+	enc := map[string]any{"url": link}
+	if typ = strings.TrimSpace(typ); typ != "" {
+		enc["type"] = typ
+	}
+	if length > 0 {
+		enc["length"] = length
+	}
+	return enc
+}
+
+func feedSummary(s string) string {
+	return truncateFeedRunes(htmlToText(s), feedSummaryMaxRunes)
+}
+
+func truncateFeedRunes(s string, n int) string {
+	rs := []rune(s)
+	if len(rs) <= n {
+		return s
+	}
+	return string(rs[:n]) + "…"
+}
+
+var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// htmlToText 去掉 HTML 标签、反转义实体并压缩空白
+func htmlToText(s string) string {
+	s = htmlTagRe.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// FirstNonEmpty 返回第一个去掉首尾空白后非空的值（已去掉空白），都为空时返回空串
+func FirstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func trimStrings(vals []string) []string {
+	var out []string
+	for _, v := range vals {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFeedFetcherFormats(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/blog/feed.rss": "feed_rss.xml",
+		"/releases.atom": "feed_atom.xml",
+		"/feed.json":     "feed_json.json",
+	})
+	f := &FeedFetcher{
+		Source: "blogs",
+		URLs:   []string{srv.URL + "/blog/feed.rss", srv.URL + "/releases.atom", srv.URL + "/missing.xml", srv.URL + "/feed.json"},
+		Client: srv.Client(),
+	}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "feed", srv, items)
+}
+
+func TestFeedFetcherAllFailed(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{})
+	f := &FeedFetcher{Source: "blogs", URLs: []string{srv.URL + "/missing.xml"}, Client: srv.Client()}
+
+	if _, err := f.FetchContext(context.Background()); err == nil || !IsPermanent(err) {
+		t.Fatalf("expected permanent error for 404 feed, got %v", err)
+	}
+}
+
+func TestParseFeedRejectsUnknownFormat(t *testing.T) {
+	for _, body := range []string{`<html><body>not a feed</body></html>`, `{"items": []}`, ``} {
+		if _, _, err := parseFeed([]byte(body), nil); err == nil {
+			t.Errorf("parseFeed(%q) should fail", body)
+		}
+	}
+}
@@ -1,22 +1,201 @@
 package collector
 
-import "time"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
 
 // NewsItem 统一采集后的基础结构
 type NewsItem struct {
-	Title       string
-	URL         string
-	Source      string
+	Title  string
+	URL    string
+	Source string
 	// 只保留 description：统一用一段介绍文案，前端自行控制截断展示
 	Description string
-	PublishedAt time.Time
-	HotScore    float64
-	RawData     map[string]any
+	// OriginalTitle / OriginalDescription 翻译前的原文，仅在 Title / Description 为译文时填写
+	OriginalTitle       string
+	OriginalDescription string
+	PublishedAt         time.Time
+	HotScore            float64
+	RawData             map[string]any
 }
 
-// Fetcher 抽象每一个数据源
+// Fetcher 抽象每一个数据源。
+// FetchContext 必须尊重 ctx 的取消与 deadline：调度器会为每次执行设置超时，
+// 超时后采集器应尽快返回，避免同一数据源的多次 cron 执行堆积。
 type Fetcher interface {
+	Name() string
+	FetchContext(ctx context.Context) ([]NewsItem, error)
+}
+
+// ErrSkipped 由采集器返回，表示本次主动跳过（如 A 股休市），调度器不将其视为失败
+var ErrSkipped = errors.New("fetch skipped")
+
+// permanentError 标记不值得重试的错误，如响应解析失败、4xx 状态码
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 将错误标记为永久性错误，调度器不会在同一次执行内重试
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 判断错误链中是否包含 Permanent 标记
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// statusError 构造非 200 响应的错误：4xx（429 限流除外）视为永久性错误，5xx 等视为可重试
+func statusError(source string, code int) error {
+	err := fmt.Errorf("%s: unexpected status %d", source, code)
+	if code >= 400 && code < 500 && code != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
+
+// LegacyFetcher 只实现了无 context 版本 Fetch 的旧采集器
+type LegacyFetcher interface {
 	Name() string
 	Fetch() ([]NewsItem, error)
 }
 
+// WrapLegacy 将旧采集器适配为 Fetcher。
+// Fetch 在独立 goroutine 中执行，ctx 取消时立即返回 ctx.Err()；旧实现本身无法被中断，其结果会被丢弃。
+func WrapLegacy(f LegacyFetcher) Fetcher {
+	return &legacyFetcher{f: f}
+}
+
+type legacyFetcher struct {
+	f LegacyFetcher
+}
+
+func (l *legacyFetcher) Name() string {
+	return l.f.Name()
+}
+
+func (l *legacyFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	type result struct {
+		items []NewsItem
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		items, err := l.f.Fetch()
+		done <- result{items: items, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.items, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// defaultHTTPClient 为所有采集器共享的 HTTP 客户端。
+// 单次请求的超时由调用方通过 ctx 控制，这里的 Timeout 只是 ctx 未设置 deadline 时的兜底。
+var defaultHTTPClient = &http.Client{Timeout: 60 * time.Second}
+
+// httpClientOrDefault 返回注入的客户端，未注入时使用共享的 defaultHTTPClient
+func httpClientOrDefault(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return defaultHTTPClient
+}
+
+// getJSON 发起 GET 请求并将 JSON 响应解码到 out：响应体最多读取 maxBytes，非 200 状态码按 statusError 处理，解码失败视为永久性错误
+func getJSON(ctx context.Context, client *http.Client, source, rawURL string, header http.Header, maxBytes int64, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return Permanent(fmt.Errorf("%s: build request: %w", source, err))
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	resp, err := httpClientOrDefault(client).Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: fetch: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusError(source, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return fmt.Errorf("%s: read body: %w", source, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return Permanent(fmt.Errorf("%s: unmarshal: %w", source, err))
+	}
+	return nil
+}
+
+// browserUserAgent 部分站点拒绝非浏览器 UA，采集时统一伪装为桌面 Chrome
+const browserUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// crawlerUserAgent 抓取 robots.txt 与原文页面（链接预览、摘要）以及订阅源时表明身份，
+// 与匹配 robots.txt 规则使用的 RobotsUserAgent 一致
+const crawlerUserAgent = "Mozilla/5.0 (compatible; " + RobotsUserAgent + "/1.0)"
+
+// baseURLOrDefault 返回去掉末尾 "/" 的注入地址，未注入时使用默认地址
+func baseURLOrDefault(u, def string) string {
+	if u = strings.TrimSpace(u); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return def
+}
+
+// newCollyCollector 创建绑定 ctx 的 Colly 采集器：请求走注入客户端的 Transport（未注入时为 http.DefaultTransport），
+// 允许的域名由调用方根据实际访问的 baseURL 推导，以便测试时指向本地 httptest 服务。
+func newCollyCollector(ctx context.Context, client *http.Client, timeout time.Duration, options ...colly.CollectorOption) *colly.Collector {
+	c := colly.NewCollector(options...)
+	base := http.DefaultTransport
+	if client != nil && client.Transport != nil {
+		base = client.Transport
+	}
+	c.WithTransport(&ctxTransport{ctx: ctx, base: base})
+	c.SetRequestTimeout(timeout)
+	return c
+}
+
+// hostOf 返回 URL 的主机名（不含端口），解析失败时返回空串
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// ctxTransport 把 ctx 绑定到每个请求上，使不支持 context 的 Colly 也能在 ctx 取消时中断请求
+type ctxTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *ctxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
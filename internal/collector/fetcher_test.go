@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type stubLegacyFetcher struct {
+	delay time.Duration
+}
+
+func (s *stubLegacyFetcher) Name() string { return "stub" }
+
+func (s *stubLegacyFetcher) Fetch() ([]NewsItem, error) {
+	time.Sleep(s.delay)
+	return []NewsItem{{Title: "t", URL: "https://example.com"}}, nil
+}
+
+func TestWrapLegacyReturnsResult(t *testing.T) {
+	f := WrapLegacy(&stubLegacyFetcher{})
+	if f.Name() != "stub" {
+		t.Fatalf("Name() = %q, want %q", f.Name(), "stub")
+	}
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+}
+
+func TestWrapLegacyHonorsDeadline(t *testing.T) {
+	f := WrapLegacy(&stubLegacyFetcher{delay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := f.FetchContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("FetchContext should return soon after deadline, took %v", time.Since(start))
+	}
+}
@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// 使用 go test ./internal/collector -run Fixture -update 重新生成 testdata/golden 下的期望结果
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// fixtureRoute 将请求路径（可带查询参数）映射到 testdata 下录制好的响应文件。
+// 路由 key 形如 "/api/qt/stock/get?secid=1.000001"：路径必须完全一致，key 中出现的查询参数也必须匹配；
+// 多个 key 同时匹配时取查询参数最多（最具体）的一个。
+type fixtureRoute struct {
+	path  string
+	query url.Values
+	file  string
+}
+
+// newFixtureServer 启动一个只返回录制数据的 httptest.Server，测试结束时自动关闭；未匹配的请求返回 404
+func newFixtureServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+
+	parsed := make([]fixtureRoute, 0, len(routes))
+	for key, file := range routes {
+		u, err := url.Parse(key)
+		if err != nil {
+			t.Fatalf("invalid fixture route %q: %v", key, err)
+		}
+		parsed = append(parsed, fixtureRoute{path: u.Path, query: u.Query(), file: file})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var best *fixtureRoute
+		for i := range parsed {
+			rt := &parsed[i]
+			if rt.path != r.URL.Path || !queryMatches(rt.query, r.URL.Query()) {
+				continue
+			}
+			if best == nil || len(rt.query) > len(best.query) {
+				best = rt
+			}
+		}
+		if best == nil {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", best.file))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		body = expandQueryPlaceholder(body, r.URL.Query().Get("q"))
+		switch filepath.Ext(best.file) {
+		case ".json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		case ".xml":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// expandQueryPlaceholder 将响应中的 {{q}} 替换为请求的 q 参数（按 JSON 字符串转义），
+// 翻译 fixture 借此返回由原文派生的译文，golden 能检查每条译文是否对应正确的原文
+func expandQueryPlaceholder(body []byte, q string) []byte {
+	if !bytes.Contains(body, []byte("{{q}}")) {
+		return body
+	}
+	quoted, _ := json.Marshal(q)
+	return bytes.ReplaceAll(body, []byte("{{q}}"), quoted[1:len(quoted)-1])
+}
+
+func queryMatches(want, got url.Values) bool {
+	for k, vs := range want {
+		if len(vs) == 0 {
+			continue
+		}
+		if got.Get(k) != vs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// stubTranslation 将翻译接口指向 fixture 服务并使用默认翻译链、不带缓存，测试结束后恢复，保证采集器测试完全离线
+func stubTranslation(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	oldGoogle, oldMyMemory := googleTranslateURL, myMemoryTranslateURL
+	oldChain, oldCache := currentTranslation()
+	googleTranslateURL = srv.URL + "/translate_a/single"
+	myMemoryTranslateURL = srv.URL + "/mymemory/get"
+	SetTranslators(nil)
+	SetTranslationCache(nil)
+	t.Cleanup(func() {
+		googleTranslateURL, myMemoryTranslateURL = oldGoogle, oldMyMemory
+		SetTranslators(oldChain)
+		SetTranslationCache(oldCache)
+	})
+}
+
+// goldenItem 是 NewsItem 中与解析逻辑相关的稳定字段；PublishedAt 多数来自 time.Now()，由各测试单独断言
+type goldenItem struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+	// 原文字段仅在有翻译时出现
+	OriginalTitle       string         `json:"originalTitle,omitempty"`
+	OriginalDescription string         `json:"originalDescription,omitempty"`
+	HotScore            float64        `json:"hotScore"`
+	RawData             map[string]any `json:"rawData,omitempty"`
+}
+
+// assertGolden 将解析结果与 testdata/golden/<name>.json 比较；srv 的地址会被替换为 http://fixture，避免端口随机导致不稳定
+func assertGolden(t *testing.T, name string, srv *httptest.Server, items []NewsItem) {
+	t.Helper()
+
+	out := make([]goldenItem, 0, len(items))
+	for _, it := range items {
+		out = append(out, goldenItem{
+			Title:               it.Title,
+			URL:                 it.URL,
+			Source:              it.Source,
+			Description:         it.Description,
+			OriginalTitle:       it.OriginalTitle,
+			OriginalDescription: it.OriginalDescription,
+			HotScore:            it.HotScore,
+			RawData:             it.RawData,
+		})
+	}
+	got, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %s: %v", name, err)
+	}
+	if srv != nil {
+		got = bytes.ReplaceAll(got, []byte(srv.URL), []byte("http://fixture"))
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with -update to create)", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s mismatch with %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
+
+// sortItemsBy 按 RawData 中的整型字段排序，用于消除并发抓取带来的顺序不确定
+func sortItemsBy(items []NewsItem, key string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := items[i].RawData[key].(int)
+		b, _ := items[j].RawData[key].(int)
+		return a < b
+	})
+}
@@ -1,83 +1,374 @@
 package collector
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 )
 
-// GitHubTrendingMock 抓取 GitHub Trending，使用页上的仓库介绍（p 标签）作为详情介绍
-type GitHubTrendingMock struct{}
+const (
+	githubBaseURL        = "https://github.com"
+	githubRequestTimeout = 5 * time.Second
+	// githubDevelopersMax 开发者榜单每页人数，用于按名次计算热度
+	githubDevelopersMax = 25
+)
+
+// GitHub Trending 的时间范围
+const (
+	GitHubSinceDaily   = "daily"
+	GitHubSinceWeekly  = "weekly"
+	GitHubSinceMonthly = "monthly"
+)
+
+// githubLanguageRe 语言 slug 与 GitHub 路径一致，如 go、c++、jupyter-notebook
+var githubLanguageRe = regexp.MustCompile(`^[a-z0-9+#.\-]{1,40}$`)
+
+// githubTrendingPage 一次需要抓取的榜单页：语言（空为全部语言）× 时间范围
+type githubTrendingPage struct {
+	language string
+	since    string
+}
+
+// githubTrendingPages 按配置展开需要抓取的页面；非法语言或时间范围会被忽略，"all" 表示全部语言
+func githubTrendingPages(languages []string, since []string) []githubTrendingPage {
+	var langs []string
+	seenLang := make(map[string]bool)
+	for _, l := range languages {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l == "all" {
+			l = ""
+		}
+		if (l != "" && !githubLanguageRe.MatchString(l)) || seenLang[l] {
+			continue
+		}
+		seenLang[l] = true
+		langs = append(langs, l)
+	}
+	if len(langs) == 0 {
+		langs = []string{""}
+	}
+	var ranges []string
+	seenSince := make(map[string]bool)
+	for _, s := range since {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !IsGitHubSince(s) || seenSince[s] {
+			continue
+		}
+		seenSince[s] = true
+		ranges = append(ranges, s)
+	}
+	if len(ranges) == 0 {
+		ranges = []string{GitHubSinceDaily}
+	}
+
+	pages := make([]githubTrendingPage, 0, len(langs)*len(ranges))
+	for _, s := range ranges {
+		for _, l := range langs {
+			pages = append(pages, githubTrendingPage{language: l, since: s})
+		}
+	}
+	return pages
+}
+
+// IsGitHubSince 判断是否为 GitHub Trending 支持的时间范围
+func IsGitHubSince(s string) bool {
+	return s == GitHubSinceDaily || s == GitHubSinceWeekly || s == GitHubSinceMonthly
+}
+
+// GitHubLanguageKey 将页面展示的语言名（如 "Jupyter Notebook"）转换为与 GitHub 路径一致的小写 slug，用于过滤
+func GitHubLanguageKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// url 返回榜单页地址，section 为空表示仓库榜，"developers" 表示开发者榜
+func (p githubTrendingPage) url(baseURL, section string) string {
+	u := baseURL + "/trending"
+	if section != "" {
+		u += "/" + section
+	}
+	if p.language != "" {
+		u += "/" + url.PathEscape(p.language)
+	}
+	if p.since != GitHubSinceDaily {
+		u += "?since=" + p.since
+	}
+	return u
+}
+
+// GitHubTrendingMock 抓取 GitHub Trending 仓库榜，使用页上的仓库介绍（p 标签）作为详情介绍。
+// 可按语言与时间范围抓取多个榜单，同一仓库合并为一条，所在榜单记录在 RawData 的 since / trendingLanguages 中。
+type GitHubTrendingMock struct {
+	// Languages 要抓取的语言 slug（如 go、rust、typescript），"all" 或为空表示全部语言
+	Languages []string
+	// Since 要抓取的时间范围 daily / weekly / monthly，为空时只抓 daily
+	Since []string
+	// Client 可选，注入自定义 HTTP 客户端（使用其 Transport）；为空时使用默认 Transport
+	Client *http.Client
+	// BaseURL 可选，覆盖 github.com 站点地址，默认 githubBaseURL
+	BaseURL string
+}
 
 func (g *GitHubTrendingMock) Name() string {
 	return "github_trending"
 }
 
-func (g *GitHubTrendingMock) Fetch() ([]NewsItem, error) {
+func (g *GitHubTrendingMock) FetchContext(ctx context.Context) ([]NewsItem, error) {
 	log.Println("fetch GitHub Trending...")
 
-	c := colly.NewCollector(
-		colly.AllowedDomains("github.com"),
-		colly.UserAgent("TrendingHubBot/1.0"),
-	)
-	c.SetRequestTimeout(5 * time.Second)
-
+	baseURL := baseURLOrDefault(g.BaseURL, githubBaseURL)
 	results := make([]NewsItem, 0, 20)
+	index := make(map[string]int)
 
-	c.OnHTML("article.Box-row", func(e *colly.HTMLElement) {
-		titleSel := e.DOM.Find("h2 a")
-		if titleSel.Length() == 0 {
-			return
-		}
+	for _, page := range githubTrendingPages(g.Languages, g.Since) {
+		c := newCollyCollector(ctx, g.Client, githubRequestTimeout,
+			colly.AllowedDomains(hostOf(baseURL)),
+			colly.UserAgent("TrendingHubBot/1.0"),
+		)
+		c.OnHTML("article.Box-row", func(e *colly.HTMLElement) {
+			titleSel := e.DOM.Find("h2 a")
+			if titleSel.Length() == 0 {
+				return
+			}
+			href, exists := titleSel.Attr("href")
+			if !exists {
+				return
+			}
+			fullURL := githubBaseURL + strings.TrimSpace(href)
+			// 形如 "321 stars today" / "1,024 stars this week"
+			periodStars := 0
+			if f := strings.Fields(e.ChildText("span.float-sm-right")); len(f) > 0 {
+				periodStars = parseStars(f[0])
+			}
 
-		repoName := strings.TrimSpace(titleSel.Text())
-		href, exists := titleSel.Attr("href")
-		if !exists {
-			return
+			if idx, ok := index[fullURL]; ok {
+				addGitHubTrendingPage(results[idx].RawData, page, periodStars)
+				return
+			}
+
+			// 标题形如 "owner /\n   repo"，去掉所有空白得到 owner/repo
+			repoName := strings.Join(strings.Fields(titleSel.Text()), "")
+			stars := parseStars(e.ChildText("a[href$=\"/stargazers\"]"))
+			forks := parseStars(e.ChildText("a[href$=\"/forks\"]"))
+			language := strings.TrimSpace(e.ChildText("span[itemprop=\"programmingLanguage\"]"))
+
+			// 从 Trending 页抓取仓库简短描述（p 标签）
+			pageDesc := strings.TrimSpace(e.ChildText("p"))
+			desc := pageDesc
+			if desc == "" {
+				desc = "GitHub Trending 仓库，点击标题前往查看详情。"
+			} else if ctx.Err() == nil && !isMostlyChinese(desc) {
+				// 非汉语则翻译成中文；ctx 已取消时保留原文
+				desc = translateToChinese(ctx, g.Client, desc)
+			}
+
+			raw := map[string]any{
+				"stars":             stars,
+				"forks":             forks,
+				"since":             []string{},
+				"trendingLanguages": []string{},
+				"starsPeriod":       map[string]int{},
+				"builtBy":           parseGitHubBuiltBy(e.DOM),
+			}
+			if language != "" {
+				raw["language"] = language
+				raw["languageKey"] = GitHubLanguageKey(language)
+			}
+			addGitHubTrendingPage(raw, page, periodStars)
+
+			index[fullURL] = len(results)
+			results = append(results, NewsItem{
+				Title:               repoName,
+				URL:                 fullURL,
+				Source:              "github",
+				Description:         desc,
+				OriginalDescription: untranslated(pageDesc, desc),
+				PublishedAt:         time.Now(),
+				HotScore:            float64(stars),
+				RawData:             raw,
+			})
+		})
+
+		pageURL := page.url(baseURL, "")
+		if err := c.Visit(pageURL); err != nil {
+			log.Printf("fetch GitHub Trending %s failed: %v", pageURL, err)
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
+	}
 
-		fullURL := "https://github.com" + strings.TrimSpace(href)
+	if len(results) == 0 {
+		log.Printf("fetch GitHub Trending got 0 items")
+		return nil, nil
+	}
 
-		starsText := strings.TrimSpace(e.ChildText("a[href$=\"/stargazers\"]"))
-		stars := parseStars(starsText)
+	return results, nil
+}
 
-		// 从 Trending 页抓取仓库简短描述（p 标签）
-		pageDesc := strings.TrimSpace(e.ChildText("p"))
-		desc := pageDesc
-		if desc == "" {
-			desc = "GitHub Trending 仓库，点击标题前往查看详情。"
-		} else if !isMostlyChinese(desc) {
-			// 非汉语则翻译成中文
-			desc = translateToChinese(desc)
+// addGitHubTrendingPage 记录仓库出现在哪个榜单及该时间范围内新增的 star 数；starsToday 对应 daily 榜
+func addGitHubTrendingPage(raw map[string]any, page githubTrendingPage, periodStars int) {
+	since := raw["since"].([]string)
+	if !containsString(since, page.since) {
+		raw["since"] = append(since, page.since)
+	}
+	if page.language != "" {
+		langs := raw["trendingLanguages"].([]string)
+		if !containsString(langs, page.language) {
+			raw["trendingLanguages"] = append(langs, page.language)
 		}
+	}
+	raw["starsPeriod"].(map[string]int)[page.since] = periodStars
+	if page.since == GitHubSinceDaily {
+		raw["starsToday"] = periodStars
+	}
+}
 
-		item := NewsItem{
-			Title:       repoName,
-			URL:         fullURL,
-			Source:      "github",
-			Description: desc,
-			PublishedAt: time.Now(),
-			HotScore:    float64(stars),
-			RawData: map[string]any{
-				"stars": stars,
-			},
+// parseGitHubBuiltBy 解析 “Built by” 中的贡献者头像
+func parseGitHubBuiltBy(sel *goquery.Selection) []map[string]string {
+	builtBy := make([]map[string]string, 0, 5)
+	sel.Find("img.avatar").Each(func(_ int, img *goquery.Selection) {
+		src, _ := img.Attr("src")
+		alt, _ := img.Attr("alt")
+		login := strings.TrimPrefix(strings.TrimSpace(alt), "@")
+		if src == "" || login == "" {
+			return
 		}
-		results = append(results, item)
+		builtBy = append(builtBy, map[string]string{"login": login, "avatar": src})
 	})
+	return builtBy
+}
 
-	if err := c.Visit("https://github.com/trending"); err != nil {
-		log.Printf("fetch GitHub Trending failed: %v", err)
-		return nil, err
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubTrendingDevelopersFetcher 抓取 GitHub Trending 开发者榜（/trending/developers），
+// 与仓库榜使用相同的语言与时间范围配置，写入独立的 github_developers 数据源。
+type GitHubTrendingDevelopersFetcher struct {
+	// Languages 要抓取的语言 slug，"all" 或为空表示全部语言
+	Languages []string
+	// Since 要抓取的时间范围 daily / weekly / monthly，为空时只抓 daily
+	Since []string
+	// Client 可选，注入自定义 HTTP 客户端（使用其 Transport）；为空时使用默认 Transport
+	Client *http.Client
+	// BaseURL 可选，覆盖 github.com 站点地址，默认 githubBaseURL
+	BaseURL string
+}
+
+func (g *GitHubTrendingDevelopersFetcher) Name() string {
+	return "github_developers"
+}
+
+func (g *GitHubTrendingDevelopersFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch GitHub Trending developers...")
+
+	baseURL := baseURLOrDefault(g.BaseURL, githubBaseURL)
+	results := make([]NewsItem, 0, githubDevelopersMax)
+	index := make(map[string]int)
+
+	for _, page := range githubTrendingPages(g.Languages, g.Since) {
+		c := newCollyCollector(ctx, g.Client, githubRequestTimeout,
+			colly.AllowedDomains(hostOf(baseURL)),
+			colly.UserAgent("TrendingHubBot/1.0"),
+		)
+		rank := 0
+		c.OnHTML("article.Box-row", func(e *colly.HTMLElement) {
+			nameSel := e.DOM.Find("h1.h3 a").First()
+			href, exists := nameSel.Attr("href")
+			if !exists {
+				return
+			}
+			rank++
+			login := strings.Trim(strings.TrimSpace(href), "/")
+			fullURL := githubBaseURL + "/" + login
+			if idx, ok := index[fullURL]; ok {
+				raw := results[idx].RawData
+				if since := raw["since"].([]string); !containsString(since, page.since) {
+					raw["since"] = append(since, page.since)
+				}
+				if rank < raw["rank"].(int) {
+					raw["rank"] = rank
+					results[idx].HotScore = float64(githubDevelopersMax - rank + 1)
+				}
+				return
+			}
+
+			name := strings.Join(strings.Fields(nameSel.Text()), " ")
+			if name == "" {
+				name = login
+			}
+			avatar, _ := e.DOM.Find("img.avatar-user").First().Attr("src")
+			repoSel := e.DOM.Find("h1.h4 a").First()
+			repoName := strings.TrimSpace(repoSel.Text())
+			repoDesc := strings.TrimSpace(e.DOM.Find("div.f6.mt-1").First().Text())
+
+			desc := "GitHub Trending 开发者，点击查看主页。"
+			origDesc := ""
+			if repoName != "" {
+				translated := repoDesc
+				if repoDesc != "" && ctx.Err() == nil && !isMostlyChinese(repoDesc) {
+					translated = translateToChinese(ctx, g.Client, repoDesc)
+				}
+				desc = "热门仓库 " + repoName
+				if repoDesc != "" {
+					desc += "：" + translated
+					origDesc = untranslated("热门仓库 "+repoName+"："+repoDesc, desc)
+				}
+			}
+
+			raw := map[string]any{
+				"login":  login,
+				"avatar": avatar,
+				"rank":   rank,
+				"since":  []string{page.since},
+			}
+			if repoName != "" {
+				repoHref, _ := repoSel.Attr("href")
+				raw["popularRepo"] = repoName
+				raw["popularRepoUrl"] = githubBaseURL + strings.TrimSpace(repoHref)
+			}
+
+			index[fullURL] = len(results)
+			results = append(results, NewsItem{
+				Title:               name + " (" + login + ")",
+				URL:                 fullURL,
+				Source:              "github_developers",
+				Description:         desc,
+				OriginalDescription: origDesc,
+				PublishedAt:         time.Now(),
+				HotScore:            float64(githubDevelopersMax - rank + 1),
+				RawData:             raw,
+			})
+		})
+
+		pageURL := page.url(baseURL, "developers")
+		if err := c.Visit(pageURL); err != nil {
+			log.Printf("fetch GitHub Trending developers %s failed: %v", pageURL, err)
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(results) == 0 {
-		log.Printf("fetch GitHub Trending got 0 items")
+		log.Printf("fetch GitHub Trending developers got 0 items")
 		return nil, nil
 	}
-
 	return results, nil
 }
 
@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGitHubTrendingFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/trending":           "github_trending.html",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &GitHubTrendingMock{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "github_trending", srv, items)
+}
+
+func TestGitHubTrendingLanguageAndSince(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/trending/go":              "github_trending.html",
+		"/trending/go?since=weekly": "github_trending_go_weekly.html",
+		"/translate_a/single":       "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &GitHubTrendingMock{
+		Languages: []string{"Go", "../etc"},
+		Since:     []string{"daily", "weekly", "yearly"},
+		Client:    srv.Client(),
+		BaseURL:   srv.URL,
+	}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "github_trending_go", srv, items)
+}
+
+func TestGitHubTrendingDevelopersFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/trending/developers": "github_developers.html",
+		"/translate_a/single":  "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &GitHubTrendingDevelopersFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "github_developers", srv, items)
+}
+
+func TestGitHubLanguageKey(t *testing.T) {
+	cases := map[string]string{
+		"Go":               "go",
+		"Jupyter Notebook": "jupyter-notebook",
+		" C++ ":            "c++",
+	}
+	for in, want := range cases {
+		if got := GitHubLanguageKey(in); got != want {
+			t.Fatalf("GitHubLanguageKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseStars(t *testing.T) {
+	cases := map[string]int{
+		"124,567": 124567,
+		"12.3k":   12300,
+		" 87 ":    87,
+		"":        0,
+		"n/a":     0,
+	}
+	for in, want := range cases {
+		if got := parseStars(in); got != want {
+			t.Fatalf("parseStars(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -12,13 +13,23 @@ import (
 	"time"
 )
 
-const goldMaxResponseBytes = 64 * 1024 // 64KB，黄金 API 响应很小
+const (
+	goldDefaultAPIURL    = "https://data-asg.goldprice.org/dbXRates/CNY"
+	goldMaxResponseBytes = 64 * 1024 // 64KB，黄金 API 响应很小
+	goldRequestTimeout   = 5 * time.Second
+)
+
 var goldAllowedHosts = []string{"data-asg.goldprice.org", "data-goldprice.org"}
 
 // GoldPriceFetcher 从外部 API 拉取黄金价格，存储为人民币/盎司；前端展示时按 1 盎司=31.1034768 克换算为元/克。
 // 默认使用 data-asg.goldprice.org 的 CNY 接口，
 // 可通过环境变量 GOLD_API_URL 覆盖。
-type GoldPriceFetcher struct{}
+type GoldPriceFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// APIURL 可选，由代码直接注入的接口地址（如测试），不经白名单校验；为空时按 GOLD_API_URL / 默认地址选择
+	APIURL string
+}
 
 func (g *GoldPriceFetcher) Name() string {
 	return "gold_price"
@@ -35,28 +46,39 @@ type goldAPIResp struct {
 	} `json:"items"`
 }
 
-func (g *GoldPriceFetcher) Fetch() ([]NewsItem, error) {
-	apiURL := os.Getenv("GOLD_API_URL")
+func (g *GoldPriceFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	apiURL := g.APIURL
 	if apiURL == "" {
-		apiURL = "https://data-asg.goldprice.org/dbXRates/CNY"
-	} else if !isAllowedGoldAPIURL(apiURL) {
-		log.Printf("fetch gold price: GOLD_API_URL host not in whitelist, ignoring")
-		apiURL = "https://data-asg.goldprice.org/dbXRates/CNY"
+		apiURL = os.Getenv("GOLD_API_URL")
+		if apiURL == "" {
+			apiURL = goldDefaultAPIURL
+		} else if !isAllowedGoldAPIURL(apiURL) {
+			log.Printf("fetch gold price: GOLD_API_URL host not in whitelist, ignoring")
+			apiURL = goldDefaultAPIURL
+		}
 	}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-
-	resp, err := client.Get(apiURL)
+	ctx, cancel := context.WithTimeout(ctx, goldRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClientOrDefault(g.Client).Do(req)
 	if err != nil {
 		log.Printf("fetch gold price failed: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("gold_price", resp.StatusCode)
+	}
+
 	var data goldAPIResp
 	if err := json.NewDecoder(io.LimitReader(resp.Body, goldMaxResponseBytes)).Decode(&data); err != nil {
 		log.Printf("decode gold price response failed: %v", err)
-		return nil, err
+		return nil, Permanent(err)
 	}
 
 	// 取第一条黄金价格
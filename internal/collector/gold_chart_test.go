@@ -0,0 +1,43 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGoldPriceFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/dbXRates/CNY": "goldprice_cny.json",
+	})
+	f := &GoldPriceFetcher{Client: srv.Client(), APIURL: srv.URL + "/dbXRates/CNY"}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	if want := time.UnixMilli(1729065600000); !items[0].PublishedAt.Equal(want) {
+		t.Fatalf("PublishedAt = %v, want %v", items[0].PublishedAt, want)
+	}
+	assertGolden(t, "gold_price", srv, items)
+}
+
+func TestIsAllowedGoldAPIURL(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want bool
+	}{
+		{"https://data-asg.goldprice.org/dbXRates/CNY", true},
+		{"https://data-goldprice.org/dbXRates/USD", true},
+		{"http://data-asg.goldprice.org/dbXRates/CNY", false},
+		{"https://evil.example.com/dbXRates/CNY", false},
+	}
+	for _, c := range cases {
+		if got := isAllowedGoldAPIURL(c.raw); got != c.want {
+			t.Fatalf("isAllowedGoldAPIURL(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
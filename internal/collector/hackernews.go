@@ -1,26 +1,70 @@
 package collector
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	hnBaseURL             = "https://hacker-news.firebaseio.com/v0"
-	hnMaxItems            = 30
-	hnMaxResponseBytes    = 1 << 20 // 1MB
-	hnConcurrency         = 10
-	hnClientTimeout       = 10 * time.Second
-	hnItemClientTimeout   = 5 * time.Second
+	hnBaseURL            = "https://hacker-news.firebaseio.com/v0"
+	hnMaxItems           = 30
+	hnMaxResponseBytes   = 1 << 20 // 1MB
+	hnConcurrency        = 10
+	hnRequestTimeout     = 10 * time.Second
+	hnItemRequestTimeout = 5 * time.Second
+	// hnMaxComments 每条故事最多抓取的评论数，hnCommentMaxRunes 单条评论保留的字符数
+	hnMaxComments     = 10
+	hnCommentMaxRunes = 150
 )
 
-// HackerNewsFetcher 通过官方 Firebase API 抓取 Hacker News 热门故事
-type HackerNewsFetcher struct{}
+// Hacker News 官方 API 提供的榜单
+const (
+	HNListTop  = "topstories"
+	HNListBest = "beststories"
+	HNListNew  = "newstories"
+	HNListAsk  = "askstories"
+	HNListShow = "showstories"
+	HNListJobs = "jobstories"
+)
+
+var hnLists = []string{HNListTop, HNListBest, HNListNew, HNListAsk, HNListShow, HNListJobs}
+
+// HNListName 规范化榜单名，同时接受简写（top / best / new / ask / show / job / jobs）
+func HNListName(s string) (string, bool) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "stories")
+	if s == "jobs" {
+		s = "job"
+	}
+	s += "stories"
+	for _, l := range hnLists {
+		if l == s {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// HackerNewsFetcher 通过官方 Firebase API 抓取 Hacker News 榜单。
+// 可同时抓取多个榜单，同一故事合并为一条，所在榜单记录在 RawData 的 list / lists / listRanks 中。
+type HackerNewsFetcher struct {
+	// Lists 要抓取的榜单（topstories / beststories / newstories / askstories / showstories / jobstories），为空时只抓 topstories
+	Lists []string
+	// MaxItems 每个榜单最多抓取的条数，为 0 时使用 hnMaxItems
+	MaxItems int
+	// Comments 每条故事额外抓取的热门评论数（按 HN 排序取前 N 条），为 0 时不抓取
+	Comments int
+	// Client 可选，注入自定义 HTTP 客户端（如测试中的 httptest）；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 Firebase API 地址，默认 hnBaseURL
+	BaseURL string
+}
 
 func (h *HackerNewsFetcher) Name() string {
 	return "hackernews_top"
@@ -30,92 +74,151 @@ type hnItem struct {
 	ID          int    `json:"id"`
 	Title       string `json:"title"`
 	URL         string `json:"url"`
+	Text        string `json:"text"`
 	Score       int    `json:"score"`
 	Descendants int    `json:"descendants"`
 	By          string `json:"by"`
 	Time        int64  `json:"time"`
 	Type        string `json:"type"`
+	Kids        []int  `json:"kids"`
+	Deleted     bool   `json:"deleted"`
+	Dead        bool   `json:"dead"`
 }
 
-func (h *HackerNewsFetcher) Fetch() ([]NewsItem, error) {
-	log.Println("fetch Hacker News Top Stories...")
-
-	client := &http.Client{Timeout: hnClientTimeout}
+// hnListEntry 记录故事出现在哪些榜单及名次
+type hnListEntry struct {
+	id    int
+	lists []string
+	ranks map[string]int
+	best  int
+}
 
-	resp, err := client.Get(hnBaseURL + "/topstories.json")
-	if err != nil {
-		return nil, fmt.Errorf("hackernews: fetch top stories: %w", err)
+func (h *HackerNewsFetcher) lists() []string {
+	seen := make(map[string]bool, len(h.Lists))
+	var out []string
+	for _, l := range h.Lists {
+		name, ok := HNListName(l)
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("hackernews: unexpected status %d", resp.StatusCode)
+	if len(out) == 0 {
+		return []string{HNListTop}
 	}
+	return out
+}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, hnMaxResponseBytes))
-	if err != nil {
-		return nil, fmt.Errorf("hackernews: read top stories: %w", err)
-	}
+func (h *HackerNewsFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	lists := h.lists()
+	log.Printf("fetch Hacker News %v...", lists)
 
-	var ids []int
-	if err := json.Unmarshal(body, &ids); err != nil {
-		return nil, fmt.Errorf("hackernews: unmarshal top stories: %w", err)
+	client := httpClientOrDefault(h.Client)
+	baseURL := baseURLOrDefault(h.BaseURL, hnBaseURL)
+	maxItems := h.MaxItems
+	if maxItems <= 0 {
+		maxItems = hnMaxItems
 	}
 
-	if len(ids) > hnMaxItems {
-		ids = ids[:hnMaxItems]
+	// 按榜单顺序合并 ID；单个榜单失败只记日志，全部失败才返回错误
+	var (
+		entries []*hnListEntry
+		byID    = make(map[int]*hnListEntry)
+		lastErr error
+	)
+	for _, list := range lists {
+		ids, err := fetchHNList(ctx, client, baseURL, list)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("hackernews: %w", ctx.Err())
+			}
+			log.Printf("hackernews: fetch %s: %v", list, err)
+			lastErr = err
+			continue
+		}
+		if len(ids) > maxItems {
+			ids = ids[:maxItems]
+		}
+		for i, id := range ids {
+			rank := i + 1
+			e, ok := byID[id]
+			if !ok {
+				e = &hnListEntry{id: id, ranks: make(map[string]int), best: rank}
+				byID[id] = e
+				entries = append(entries, e)
+			}
+			e.lists = append(e.lists, list)
+			e.ranks[list] = rank
+			if rank < e.best {
+				e.best = rank
+			}
+		}
+	}
+	if len(entries) == 0 && lastErr != nil {
+		return nil, lastErr
 	}
 
 	type indexedItem struct {
-		idx  int
-		item hnItem
+		entry    *hnListEntry
+		item     hnItem
+		comments []map[string]any
 	}
 
 	var (
-		mu      sync.Mutex
-		wg      sync.WaitGroup
-		sem     = make(chan struct{}, hnConcurrency)
-		items   = make([]indexedItem, 0, len(ids))
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, hnConcurrency)
+		items = make([]indexedItem, 0, len(entries))
 	)
 
-	itemClient := &http.Client{Timeout: hnItemClientTimeout}
-
-	for i, id := range ids {
+fetchLoop:
+	for _, e := range entries {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break fetchLoop
+		}
 		wg.Add(1)
-		sem <- struct{}{}
-		go func(idx, id int) {
+		go func(e *hnListEntry) {
 			defer wg.Done()
 			defer func() { <-sem }()
 
-			it, err := fetchHNItem(itemClient, id)
+			it, err := fetchHNItem(ctx, client, baseURL, e.id)
 			if err != nil {
-				log.Printf("hackernews: fetch item %d: %v", id, err)
+				log.Printf("hackernews: fetch item %d: %v", e.id, err)
 				return
 			}
-			if it.Title == "" || it.Type != "story" {
+			// 招聘帖只在 jobstories 榜单中保留
+			_, inJobs := e.ranks[HNListJobs]
+			if it.Title == "" || (it.Type != "story" && !(it.Type == "job" && inJobs)) {
 				return
 			}
+			comments := h.fetchComments(ctx, client, baseURL, it.Kids)
 
 			mu.Lock()
-			items = append(items, indexedItem{idx: idx, item: it})
+			items = append(items, indexedItem{entry: e, item: it, comments: comments})
 			mu.Unlock()
-		}(i, id)
+		}(e)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("hackernews: %w", err)
+	}
 
-	// 并发翻译标题，避免串行调用外部 API 导致整体超时
+	// 并发翻译标题，避免串行调用翻译 API 导致整体超时
 	type translatedResult struct {
-		idx        int
+		entry      *hnListEntry
 		item       hnItem
-		rank       int
 		translated string
+		comments   []map[string]any
 	}
 
 	var (
-		twg     sync.WaitGroup
-		tmu     sync.Mutex
-		tsem    = make(chan struct{}, 3)
-		tItems  = make([]translatedResult, 0, len(items))
+		twg    sync.WaitGroup
+		tmu    sync.Mutex
+		tsem   = make(chan struct{}, 3)
+		tItems = make([]translatedResult, 0, len(items))
 	)
 
 	for _, ii := range items {
@@ -125,17 +228,17 @@ func (h *HackerNewsFetcher) Fetch() ([]NewsItem, error) {
 			defer twg.Done()
 			defer func() { <-tsem }()
 
+			// ctx 取消后不再发起新的翻译，剩余条目保留原标题
 			translated := ii.item.Title
-			if !isMostlyChinese(translated) {
-				translated = translateToChinese(translated)
+			if ctx.Err() == nil && !isMostlyChinese(translated) {
+				translated = translateToChinese(ctx, client, translated)
 			}
-
 			tmu.Lock()
 			tItems = append(tItems, translatedResult{
-				idx:        ii.idx,
+				entry:      ii.entry,
 				item:       ii.item,
-				rank:       ii.idx + 1,
 				translated: translated,
+				comments:   ii.comments,
 			})
 			tmu.Unlock()
 		}(ii)
@@ -151,21 +254,33 @@ func (h *HackerNewsFetcher) Fetch() ([]NewsItem, error) {
 			itemURL = fmt.Sprintf("https://news.ycombinator.com/item?id=%d", it.ID)
 		}
 
+		raw := map[string]any{
+			"hn_id":     it.ID,
+			"author":    it.By,
+			"comments":  it.Descendants,
+			"score":     it.Score,
+			"rank":      tr.entry.best,
+			"list":      tr.entry.lists[0],
+			"lists":     tr.entry.lists,
+			"listRanks": tr.entry.ranks,
+		}
+		description, origDescription := tr.translated, it.Title
+		if len(tr.comments) > 0 {
+			raw["topComments"] = tr.comments
+			description = hnDescriptionWithComments(tr.translated, tr.comments)
+			origDescription = hnDescriptionWithComments(it.Title, tr.comments)
+		}
+
 		results = append(results, NewsItem{
-			Title:       tr.translated,
-			URL:         itemURL,
-			Source:      "hackernews",
-			Description: tr.translated,
-			PublishedAt: time.Unix(it.Time, 0),
-			HotScore:    float64(it.Score),
-			RawData: map[string]any{
-				"hn_id":          it.ID,
-				"original_title": it.Title,
-				"author":         it.By,
-				"comments":       it.Descendants,
-				"score":          it.Score,
-				"rank":           tr.rank,
-			},
+			Title:               tr.translated,
+			URL:                 itemURL,
+			Source:              "hackernews",
+			Description:         description,
+			OriginalTitle:       untranslated(it.Title, tr.translated),
+			OriginalDescription: untranslated(origDescription, description),
+			PublishedAt:         time.Unix(it.Time, 0),
+			HotScore:            float64(it.Score),
+			RawData:             raw,
 		})
 	}
 
@@ -176,9 +291,102 @@ func (h *HackerNewsFetcher) Fetch() ([]NewsItem, error) {
 	return results, nil
 }
 
-func fetchHNItem(client *http.Client, id int) (hnItem, error) {
-	url := fmt.Sprintf("%s/item/%d.json", hnBaseURL, id)
-	resp, err := client.Get(url)
+// fetchHNList 读取榜单的故事 ID 列表
+func fetchHNList(ctx context.Context, client *http.Client, baseURL, list string) ([]int, error) {
+	listCtx, cancel := context.WithTimeout(ctx, hnRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(listCtx, http.MethodGet, baseURL+"/"+list+".json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("hackernews: build request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("hackernews: fetch %s: %w", list, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("hackernews", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, hnMaxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("hackernews: read %s: %w", list, err)
+	}
+
+	var ids []int
+	if err := json.Unmarshal(body, &ids); err != nil {
+		return nil, Permanent(fmt.Errorf("hackernews: unmarshal %s: %w", list, err))
+	}
+	return ids, nil
+}
+
+// fetchComments 按 HN 排序抓取前 N 条评论（跳过已删除 / dead），评论保留原文并截断
+func (h *HackerNewsFetcher) fetchComments(ctx context.Context, client *http.Client, baseURL string, kids []int) []map[string]any {
+	n := h.Comments
+	if n <= 0 || len(kids) == 0 {
+		return nil
+	}
+	if n > hnMaxComments {
+		n = hnMaxComments
+	}
+	// 多取几条，弥补被删除的评论
+	if len(kids) > n*2 {
+		kids = kids[:n*2]
+	}
+	out := make([]map[string]any, 0, n)
+	for _, id := range kids {
+		if len(out) >= n || ctx.Err() != nil {
+			break
+		}
+		c, err := fetchHNItem(ctx, client, baseURL, id)
+		if err != nil {
+			log.Printf("hackernews: fetch comment %d: %v", id, err)
+			continue
+		}
+		if c.Type != "comment" || c.Deleted || c.Dead {
+			continue
+		}
+		text := hnCommentText(c.Text)
+		if text == "" {
+			continue
+		}
+		out = append(out, map[string]any{"id": c.ID, "by": c.By, "text": text})
+	}
+	return out
+}
+
+// hnCommentText 将评论 HTML 转为纯文本并截断
+func hnCommentText(raw string) string {
+	text := htmlToText(raw)
+	rs := []rune(text)
+	if len(rs) > hnCommentMaxRunes {
+		text = string(rs[:hnCommentMaxRunes]) + "…"
+	}
+	return text
+}
+
+// hnDescriptionWithComments 悬停详情：标题后附热门评论
+func hnDescriptionWithComments(title string, comments []map[string]any) string {
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n\n热门评论：")
+	for i, c := range comments {
+		fmt.Fprintf(&b, "\n%d. %s：%s", i+1, c["by"], c["text"])
+	}
+	return b.String()
+}
+
+func fetchHNItem(ctx context.Context, client *http.Client, baseURL string, id int) (hnItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, hnItemRequestTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/item/%d.json", baseURL, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return hnItem{}, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return hnItem{}, err
 	}
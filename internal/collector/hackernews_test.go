@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHackerNewsFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/topstories.json":    "hn_topstories.json",
+		"/item/41000001.json": "hn_item_41000001.json",
+		"/item/41000002.json": "hn_item_41000002.json",
+		"/item/41000003.json": "hn_item_41000003.json",
+		"/item/41000004.json": "hn_item_41000004.json",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &HackerNewsFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	sortItemsBy(items, "rank")
+	assertGolden(t, "hackernews", srv, items)
+}
+
+func TestHackerNewsFetcherCanceled(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/topstories.json": "hn_topstories.json",
+	})
+	f := &HackerNewsFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.FetchContext(ctx); err == nil {
+		t.Fatalf("expected error for canceled context")
+	}
+}
+
+func TestHackerNewsFetcherMultiListWithComments(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/topstories.json":    "hn_topstories.json",
+		"/beststories.json":   "hn_beststories.json",
+		"/jobstories.json":    "hn_jobstories.json",
+		"/item/41000001.json": "hn_item_41000001.json",
+		"/item/41000002.json": "hn_item_41000002.json",
+		"/item/41000003.json": "hn_item_41000003.json",
+		"/item/41000005.json": "hn_item_41000005.json",
+		"/item/41000101.json": "hn_item_41000101.json",
+		"/item/41000102.json": "hn_item_41000102.json",
+		"/item/41000103.json": "hn_item_41000103.json",
+		"/item/41000104.json": "hn_item_41000104.json",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &HackerNewsFetcher{
+		Lists:    []string{"best", "jobs", "topstories", "best", "unknown"},
+		MaxItems: 2,
+		Comments: 2,
+		Client:   srv.Client(),
+		BaseURL:  srv.URL,
+	}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	sortItemsBy(items, "hn_id")
+	assertGolden(t, "hackernews_lists", srv, items)
+}
+
+func TestHNListName(t *testing.T) {
+	cases := map[string]string{
+		"top":        HNListTop,
+		"Best":       HNListBest,
+		"askstories": HNListAsk,
+		" show ":     HNListShow,
+		"job":        HNListJobs,
+		"jobs":       HNListJobs,
+		"newstories": HNListNew,
+	}
+	for in, want := range cases {
+		if got, ok := HNListName(in); !ok || got != want {
+			t.Errorf("HNListName(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "polls", "../top"} {
+		if _, ok := HNListName(in); ok {
+			t.Errorf("HNListName(%q) should be invalid", in)
+		}
+	}
+}
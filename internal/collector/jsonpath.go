@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathStep JSONPath 中的一步：取对象字段、取数组下标或通配
+type jsonPathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath 解析 JSONPath 子集：$、.key、['key']、[n]（负数从末尾计）、[*] 与 .*。
+// 开头的 $ 可省略，如 "data.cards[0].content" 与 "$.data.cards[0].content" 等价
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	var steps []jsonPathStep
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '.':
+			i++
+			if i < len(p) && p[i] == '*' {
+				steps = append(steps, jsonPathStep{wildcard: true})
+				i++
+				continue
+			}
+			j := i
+			for j < len(p) && p[j] != '.' && p[j] != '[' {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("jsonpath %q: empty key at %d", path, i)
+			}
+			steps = append(steps, jsonPathStep{key: p[i:j]})
+			i = j
+		case '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q: missing ]", path)
+			}
+			inner := strings.TrimSpace(p[i+1 : i+end])
+			i += end + 1
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath %q: invalid index %q", path, inner)
+				}
+				steps = append(steps, jsonPathStep{index: n, isIndex: true})
+			}
+		default:
+			// 省略 $ 时的首个字段
+			if len(steps) > 0 || i > 0 {
+				return nil, fmt.Errorf("jsonpath %q: unexpected %q at %d", path, p[i], i)
+			}
+			p = "." + p
+		}
+	}
+	return steps, nil
+}
+
+// evalJSONPath 在 encoding/json 解码出的值上执行 JSONPath，返回所有命中的节点
+func evalJSONPath(v any, steps []jsonPathStep) []any {
+	nodes := []any{v}
+	for _, st := range steps {
+		var next []any
+		for _, n := range nodes {
+			switch {
+			case st.wildcard:
+				switch t := n.(type) {
+				case []any:
+					next = append(next, t...)
+				case map[string]any:
+					keys := make([]string, 0, len(t))
+					for k := range t {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						next = append(next, t[k])
+					}
+				}
+			case st.isIndex:
+				if arr, ok := n.([]any); ok {
+					idx := st.index
+					if idx < 0 {
+						idx += len(arr)
+					}
+					if idx >= 0 && idx < len(arr) {
+						next = append(next, arr[idx])
+					}
+				}
+			default:
+				if m, ok := n.(map[string]any); ok {
+					if val, ok := m[st.key]; ok {
+						next = append(next, val)
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// jsonScalarString 将 JSON 标量转为字符串，对象与数组返回空串
+func jsonScalarString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	lobstersBaseURL          = "https://lobste.rs"
+	lobstersHottestPath      = "/hottest.json"
+	lobstersMaxResponseBytes = 2 << 20 // 2MB
+	lobstersRequestTimeout   = 10 * time.Second
+)
+
+// LobstersFetcher 抓取 Lobste.rs 最热文章；标题翻译为中文，得票数作为 HotScore，评论数与标签记录在 RawData 中
+type LobstersFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 lobste.rs 站点地址，默认 lobstersBaseURL
+	BaseURL string
+}
+
+func (l *LobstersFetcher) Name() string {
+	return "lobsters_hottest"
+}
+
+type lobstersStory struct {
+	ShortID      string          `json:"short_id"`
+	Title        string          `json:"title"`
+	URL          string          `json:"url"`
+	CommentsURL  string          `json:"comments_url"`
+	Score        int             `json:"score"`
+	CommentCount int             `json:"comment_count"`
+	CreatedAt    string          `json:"created_at"`
+	Tags         []string        `json:"tags"`
+	Submitter    json.RawMessage `json:"submitter_user"`
+}
+
+// submitter 兼容 submitter_user 为字符串（新版）或 {"username": ...} 对象（旧版）
+func (s lobstersStory) submitter() string {
+	var name string
+	if err := json.Unmarshal(s.Submitter, &name); err == nil {
+		return name
+	}
+	var obj struct {
+		Username string `json:"username"`
+	}
+	_ = json.Unmarshal(s.Submitter, &obj)
+	return obj.Username
+}
+
+func (l *LobstersFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Lobsters hottest...")
+
+	reqCtx, cancel := context.WithTimeout(ctx, lobstersRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	var stories []lobstersStory
+	if err := getJSON(reqCtx, l.Client, "lobsters", baseURLOrDefault(l.BaseURL, lobstersBaseURL)+lobstersHottestPath, header, lobstersMaxResponseBytes, &stories); err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, len(stories))
+	for i, s := range stories {
+		titles[i] = strings.TrimSpace(s.Title)
+	}
+	translated := translateAllToChinese(ctx, l.Client, titles)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(stories))
+	for idx, s := range stories {
+		// 文本帖没有外链，使用讨论页地址
+		link := s.URL
+		if link == "" {
+			link = s.CommentsURL
+		}
+		if titles[idx] == "" || link == "" {
+			continue
+		}
+		published, err := time.Parse(time.RFC3339, s.CreatedAt)
+		if err != nil {
+			published = now
+		}
+		original := untranslated(titles[idx], translated[idx])
+		results = append(results, NewsItem{
+			Title:               translated[idx],
+			URL:                 link,
+			Source:              "lobsters",
+			Description:         translated[idx],
+			OriginalTitle:       original,
+			OriginalDescription: original,
+			PublishedAt:         published,
+			HotScore:            float64(s.Score),
+			RawData: map[string]any{
+				"short_id":    s.ShortID,
+				"author":      s.submitter(),
+				"score":       s.Score,
+				"comments":    s.CommentCount,
+				"commentsUrl": s.CommentsURL,
+				"tags":        s.Tags,
+				"rank":        idx + 1,
+			},
+		})
+	}
+	if len(results) == 0 {
+		log.Println("lobsters: no items fetched")
+	}
+	return results, nil
+}
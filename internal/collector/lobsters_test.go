@@ -0,0 +1,21 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLobstersFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/hottest.json":       "lobsters_hottest.json",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &LobstersFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "lobsters_hottest", srv, items)
+}
@@ -0,0 +1,65 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress 链接解析到回环、内网、链路本地等非公网地址，拒绝连接
+var ErrPrivateAddress = errors.New("refusing to connect to a non-public address")
+
+// sharedAddressSpace 运营商级 NAT 地址段（100.64.0.0/10），net.IP.IsPrivate 不包含
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicOnly 判断 IP 是否为公网地址
+func publicOnly(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() && !sharedAddressSpace.Contains(ip)
+}
+
+// dialControl 在建立连接前检查已解析的目标地址：每次连接（含重定向与 DNS 重新解析）都会经过这里，域名解析到内网的情况同样被拒绝
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !publicOnly(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+	return nil
+}
+
+// NewPublicHTTPClient 只允许连接公网地址的 HTTP 客户端，用于请求渠道配置或条目中由用户提供的链接（订阅源、声明式抓取、链接预览等），
+// 避免被用来访问内网服务与云主机元数据接口。不使用环境变量中的代理：代理会让目标地址检查失效
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// publicHTTPClient 请求用户提供的链接时共享的客户端，超时语义同 defaultHTTPClient
+var publicHTTPClient = NewPublicHTTPClient(60 * time.Second)
+
+// publicClientOrDefault 返回注入的客户端，未注入时使用只允许公网地址的 publicHTTPClient
+func publicClientOrDefault(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return publicHTTPClient
+}
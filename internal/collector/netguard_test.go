@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestPublicOnly(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+		"224.0.0.1":        false,
+	}
+	for s, want := range cases {
+		if got := publicOnly(netip.MustParseAddr(s)); got != want {
+			t.Errorf("publicOnly(%s) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestPublicClientRejectsLoopback(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = true }))
+	defer srv.Close()
+
+	// 未注入客户端的订阅源与声明式抓取只允许连接公网地址
+	f := &FeedFetcher{Source: "blogs", URLs: []string{srv.URL + "/feed.xml"}}
+	if _, err := f.FetchContext(context.Background()); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("feed fetch error = %v, want ErrPrivateAddress", err)
+	}
+	if _, err := FetchArticle(context.Background(), nil, srv.URL+"/post", 0); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("article fetch error = %v, want ErrPrivateAddress", err)
+	}
+	if hit {
+		t.Error("loopback server should not be reached")
+	}
+}
@@ -0,0 +1,171 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	papersBaseURL          = "https://huggingface.co"
+	papersTrendingPath     = "/api/daily_papers"
+	papersMaxItems         = 50
+	papersMaxResponseBytes = 8 << 20 // 8MB
+	papersRequestTimeout   = 20 * time.Second
+)
+
+// TrendingPapersFetcher 抓取热门论文榜。Papers with Code 已并入 Hugging Face Papers
+// （paperswithcode.com 的 trending 页跳转至 huggingface.co/papers/trending），这里使用其公开的 daily_papers 接口，
+// 点赞数作为 HotScore。榜单本身不含 arXiv 分类，会再用一次 arXiv id_list 查询补全作者与分类，失败时只记日志。
+// 与 ArxivFetcher 一致：标题保持原文，摘要翻译为中文作为 Description
+type TrendingPapersFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 huggingface.co 地址，默认 papersBaseURL
+	BaseURL string
+	// ArxivBaseURL 可选，覆盖 export.arxiv.org 地址，默认 arxivBaseURL
+	ArxivBaseURL string
+}
+
+func (p *TrendingPapersFetcher) Name() string {
+	return "papers_trending"
+}
+
+type hfDailyPaper struct {
+	Paper struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		Summary string `json:"summary"`
+		Authors []struct {
+			Name string `json:"name"`
+		} `json:"authors"`
+		PublishedAt string `json:"publishedAt"`
+		Upvotes     int    `json:"upvotes"`
+		GithubRepo  string `json:"githubRepo"`
+		GithubStars int    `json:"githubStars"`
+	} `json:"paper"`
+	NumComments int `json:"numComments"`
+}
+
+func (p *TrendingPapersFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch trending papers...")
+
+	reqCtx, cancel := context.WithTimeout(ctx, papersRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", crawlerUserAgent)
+	var list []hfDailyPaper
+	rawURL := baseURLOrDefault(p.BaseURL, papersBaseURL) + papersTrendingPath + "?sort=trending&limit=" + strconv.Itoa(papersMaxItems)
+	if err := getJSON(reqCtx, p.Client, "papers_trending", rawURL, header, papersMaxResponseBytes, &list); err != nil {
+		return nil, err
+	}
+
+	// 以 arXiv 编号去重，保持榜单顺序
+	var (
+		papers []arxivPaper
+		extra  []hfDailyPaper
+		seen   = make(map[string]bool)
+	)
+	for _, d := range list {
+		id, _ := splitArxivID(d.Paper.ID)
+		title := strings.Join(strings.Fields(d.Paper.Title), " ")
+		if id == "" || title == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		paper := arxivPaper{
+			id:        id,
+			title:     title,
+			abstract:  strings.Join(strings.Fields(d.Paper.Summary), " "),
+			published: parseFeedTime(d.Paper.PublishedAt),
+		}
+		for _, au := range d.Paper.Authors {
+			if name := strings.TrimSpace(au.Name); name != "" {
+				paper.authors = append(paper.authors, name)
+			}
+		}
+		papers = append(papers, paper)
+		extra = append(extra, d)
+	}
+	p.enrichFromArxiv(ctx, papers)
+
+	abstracts := make([]string, len(papers))
+	for i, paper := range papers {
+		abstracts[i] = paper.abstract
+	}
+	translated := translateAllToChinese(ctx, p.Client, abstracts)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(papers))
+	for i, paper := range papers {
+		published := paper.published
+		if published.IsZero() {
+			published = now
+		}
+		raw := paper.rawData()
+		raw["upvotes"] = extra[i].Paper.Upvotes
+		raw["comments"] = extra[i].NumComments
+		raw["rank"] = i + 1
+		if repo := extra[i].Paper.GithubRepo; isHTTPURL(repo) {
+			raw["githubRepo"] = repo
+			raw["githubStars"] = extra[i].Paper.GithubStars
+		}
+		results = append(results, NewsItem{
+			Title:               paper.title,
+			URL:                 arxivAbsURL + paper.id,
+			Source:              "papers",
+			Description:         translated[i],
+			OriginalDescription: untranslated(paper.abstract, translated[i]),
+			PublishedAt:         published,
+			HotScore:            float64(extra[i].Paper.Upvotes),
+			RawData:             raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Println("papers_trending: no items fetched")
+	}
+	return results, nil
+}
+
+// enrichFromArxiv 用 arXiv id_list 查询补全分类、主分类、版本与作者（榜单中的作者可能不全）
+func (p *TrendingPapersFetcher) enrichFromArxiv(ctx context.Context, papers []arxivPaper) {
+	if len(papers) == 0 {
+		return
+	}
+	ids := make([]string, len(papers))
+	for i, paper := range papers {
+		ids[i] = paper.id
+	}
+	query := url.Values{}
+	query.Set("id_list", strings.Join(ids, ","))
+	query.Set("max_results", strconv.Itoa(len(ids)))
+	meta, err := fetchArxivPapers(ctx, p.Client, p.ArxivBaseURL, query)
+	if err != nil {
+		log.Printf("papers_trending: arxiv metadata: %v", err)
+		return
+	}
+	byID := make(map[string]arxivPaper, len(meta))
+	for _, m := range meta {
+		byID[m.id] = m
+	}
+	for i := range papers {
+		m, ok := byID[papers[i].id]
+		if !ok {
+			continue
+		}
+		papers[i].version = m.version
+		papers[i].categories = m.categories
+		papers[i].primaryCategory = m.primaryCategory
+		papers[i].comment = m.comment
+		if len(m.authors) > len(papers[i].authors) {
+			papers[i].authors = m.authors
+		}
+		if papers[i].abstract == "" {
+			papers[i].abstract = m.abstract
+		}
+	}
+}
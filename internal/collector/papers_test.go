@@ -0,0 +1,22 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrendingPapersFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/api/daily_papers":                        "papers_trending.json",
+		"/api/query?id_list=2510.01234,2510.09999": "arxiv_id_list.xml",
+		"/translate_a/single":                      "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &TrendingPapersFetcher{Client: srv.Client(), BaseURL: srv.URL, ArxivBaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "papers_trending", srv, items)
+}
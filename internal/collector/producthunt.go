@@ -0,0 +1,227 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	productHuntAPIURL           = "https://api.producthunt.com"
+	productHuntGraphQLPath      = "/v2/api/graphql"
+	productHuntFeedURL          = "https://www.producthunt.com/feed"
+	productHuntMaxItems         = 30
+	productHuntMaxResponseBytes = 2 << 20 // 2MB
+	productHuntRequestTimeout   = 15 * time.Second
+)
+
+// productHuntQuery 按得票数取当天发布的产品
+const productHuntQuery = `query($first: Int!, $postedAfter: DateTime!) {
+  posts(first: $first, order: VOTES, postedAfter: $postedAfter) {
+    edges { node { id name tagline slug url website votesCount commentsCount createdAt
+      topics(first: 5) { edges { node { name } } } } }
+  }
+}`
+
+// ProductHuntFetcher 抓取 Product Hunt 当日产品。配置了 Token 时调用官方 GraphQL API，得票数作为 HotScore，
+// 评论数与得票数记录在 RawData 中；未配置时退回公开的 Atom 订阅，按排名计分。产品名保持原文，简介翻译为中文
+type ProductHuntFetcher struct {
+	// Token Product Hunt API 的 Developer Token，为空时使用 Atom 订阅
+	Token string
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 api.producthunt.com 地址，默认 productHuntAPIURL
+	BaseURL string
+	// FeedURL 可选，覆盖 Atom 订阅地址，默认 productHuntFeedURL
+	FeedURL string
+}
+
+func (p *ProductHuntFetcher) Name() string {
+	return "producthunt_daily"
+}
+
+type productHuntPost struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Tagline       string `json:"tagline"`
+	Slug          string `json:"slug"`
+	URL           string `json:"url"`
+	Website       string `json:"website"`
+	VotesCount    int    `json:"votesCount"`
+	CommentsCount int    `json:"commentsCount"`
+	CreatedAt     string `json:"createdAt"`
+	Topics        struct {
+		Edges []struct {
+			Node struct {
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"topics"`
+}
+
+type productHuntResponse struct {
+	Data struct {
+		Posts struct {
+			Edges []struct {
+				Node productHuntPost `json:"node"`
+			} `json:"edges"`
+		} `json:"posts"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (p *ProductHuntFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	if strings.TrimSpace(p.Token) == "" {
+		return p.fetchFeed(ctx)
+	}
+	log.Println("fetch Product Hunt daily (api)...")
+
+	posts, err := p.fetchPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taglines := make([]string, len(posts))
+	for i, post := range posts {
+		taglines[i] = strings.TrimSpace(post.Tagline)
+	}
+	translated := translateAllToChinese(ctx, p.Client, taglines)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(posts))
+	for i, post := range posts {
+		name := strings.TrimSpace(post.Name)
+		if name == "" || post.URL == "" {
+			continue
+		}
+		published, err := time.Parse(time.RFC3339, post.CreatedAt)
+		if err != nil {
+			published = now
+		}
+		topics := make([]string, 0, len(post.Topics.Edges))
+		for _, e := range post.Topics.Edges {
+			topics = append(topics, e.Node.Name)
+		}
+		results = append(results, NewsItem{
+			Title:               name,
+			URL:                 post.URL,
+			Source:              "producthunt",
+			Description:         translated[i],
+			OriginalDescription: untranslated(taglines[i], translated[i]),
+			PublishedAt:         published,
+			HotScore:            float64(post.VotesCount),
+			RawData: map[string]any{
+				"producthunt_id": post.ID,
+				"website":        post.Website,
+				"votes":          post.VotesCount,
+				"comments":       post.CommentsCount,
+				"topics":         topics,
+				"rank":           i + 1,
+			},
+		})
+	}
+	return results, nil
+}
+
+// fetchPosts 调用 GraphQL 接口，返回最近 24 小时内发布、按得票数排序的产品
+func (p *ProductHuntFetcher) fetchPosts(ctx context.Context) ([]productHuntPost, error) {
+	ctx, cancel := context.WithTimeout(ctx, productHuntRequestTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]any{
+		"query": productHuntQuery,
+		"variables": map[string]any{
+			"first":       productHuntMaxItems,
+			"postedAfter": time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("producthunt: marshal query: %w", err))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURLOrDefault(p.BaseURL, productHuntAPIURL)+productHuntGraphQLPath, bytes.NewReader(payload))
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("producthunt: build request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(p.Token))
+	resp, err := httpClientOrDefault(p.Client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("producthunt: fetch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("producthunt", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, productHuntMaxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("producthunt: read body: %w", err)
+	}
+	var data productHuntResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, Permanent(fmt.Errorf("producthunt: unmarshal: %w", err))
+	}
+	if len(data.Errors) > 0 {
+		return nil, Permanent(fmt.Errorf("producthunt: graphql: %s", data.Errors[0].Message))
+	}
+	posts := make([]productHuntPost, 0, len(data.Data.Posts.Edges))
+	for _, e := range data.Data.Posts.Edges {
+		posts = append(posts, e.Node)
+	}
+	return posts, nil
+}
+
+// fetchFeed 未配置 Token 时读取公开 Atom 订阅；订阅不含得票数，按条目顺序计分
+func (p *ProductHuntFetcher) fetchFeed(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Product Hunt daily (feed)...")
+
+	feedURL := baseURLOrDefault(p.FeedURL, productHuntFeedURL)
+	_, entries, err := (&FeedFetcher{Source: "producthunt", Client: p.Client}).fetchFeed(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > productHuntMaxItems {
+		entries = entries[:productHuntMaxItems]
+	}
+	taglines := make([]string, len(entries))
+	for i, e := range entries {
+		taglines[i] = e.summary
+	}
+	translated := translateAllToChinese(ctx, p.Client, taglines)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(entries))
+	for i, e := range entries {
+		if e.title == "" {
+			continue
+		}
+		published := e.published
+		if published.IsZero() {
+			published = now
+		}
+		raw := map[string]any{
+			"rank": i + 1,
+		}
+		if e.author != "" {
+			raw["author"] = e.author
+		}
+		results = append(results, NewsItem{
+			Title:               e.title,
+			URL:                 e.link,
+			Source:              "producthunt",
+			Description:         translated[i],
+			OriginalDescription: untranslated(taglines[i], translated[i]),
+			PublishedAt:         published,
+			HotScore:            float64(len(entries) - i),
+			RawData:             raw,
+		})
+	}
+	return results, nil
+}
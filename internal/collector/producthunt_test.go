@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProductHuntFetcherAPIFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/v2/api/graphql":     "producthunt_graphql.json",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &ProductHuntFetcher{Token: "test-token", Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "producthunt_api", srv, items)
+}
+
+func TestProductHuntFetcherFeedFallback(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/feed":               "producthunt_feed.xml",
+		"/translate_a/single": "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &ProductHuntFetcher{Client: srv.Client(), BaseURL: srv.URL, FeedURL: srv.URL + "/feed"}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "producthunt_feed", srv, items)
+}
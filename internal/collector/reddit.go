@@ -0,0 +1,171 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	redditBaseURL          = "https://www.reddit.com"
+	redditMaxItems         = 25
+	redditMaxResponseBytes = 4 << 20 // 4MB
+	redditRequestTimeout   = 10 * time.Second
+	// Reddit 会拒绝通用 UA，按其 API 规范使用带项目标识的 UA
+	redditUserAgent = "TrendingHub/1.0 (+https://github.com/LJTian/TrendingHub)"
+)
+
+// RedditDefaultSubreddits 未配置 subreddits 时抓取的版块
+var RedditDefaultSubreddits = []string{"programming", "golang", "technology"}
+
+var redditSubredditRe = regexp.MustCompile(`^[A-Za-z0-9_]{2,21}$`)
+
+// RedditFetcher 通过 JSON listing 抓取若干 subreddit 的热门帖子；标题翻译为中文，得票数作为 HotScore。
+// 同一链接出现在多个版块时合并为一条，版块记录在 RawData 的 subreddit / subreddits 中。置顶帖与 NSFW 帖会被跳过
+type RedditFetcher struct {
+	// Subreddits 版块名（不含 r/ 前缀），为空时使用 RedditDefaultSubreddits
+	Subreddits []string
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 www.reddit.com 站点地址，默认 redditBaseURL
+	BaseURL string
+}
+
+func (r *RedditFetcher) Name() string {
+	return "reddit_hot"
+}
+
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Kind string     `json:"kind"`
+			Data redditPost `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+type redditPost struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	URL         string  `json:"url"`
+	Permalink   string  `json:"permalink"`
+	Subreddit   string  `json:"subreddit"`
+	Author      string  `json:"author"`
+	Score       int     `json:"score"`
+	NumComments int     `json:"num_comments"`
+	CreatedUTC  float64 `json:"created_utc"`
+	IsSelf      bool    `json:"is_self"`
+	Stickied    bool    `json:"stickied"`
+	Over18      bool    `json:"over_18"`
+	Selftext    string  `json:"selftext"`
+}
+
+// subreddits 返回去重、校验后的版块列表；非法名称会被忽略
+func (r *RedditFetcher) subreddits() []string {
+	seen := make(map[string]bool, len(r.Subreddits))
+	var out []string
+	for _, s := range r.Subreddits {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "r/")
+		key := strings.ToLower(s)
+		if !redditSubredditRe.MatchString(s) || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	if len(out) == 0 {
+		return RedditDefaultSubreddits
+	}
+	return out
+}
+
+func (r *RedditFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	subs := r.subreddits()
+	log.Printf("fetch Reddit %v...", subs)
+
+	baseURL := baseURLOrDefault(r.BaseURL, redditBaseURL)
+	header := http.Header{}
+	header.Set("User-Agent", redditUserAgent)
+
+	// 按版块顺序合并；单个版块失败只记日志，全部失败才返回错误
+	var (
+		posts   []redditPost
+		subsOf  = make(map[string][]string)
+		lastErr error
+	)
+	for _, sub := range subs {
+		reqCtx, cancel := context.WithTimeout(ctx, redditRequestTimeout)
+		var listing redditListing
+		err := getJSON(reqCtx, r.Client, "reddit", fmt.Sprintf("%s/r/%s/hot.json?limit=%d&raw_json=1", baseURL, sub, redditMaxItems), header, redditMaxResponseBytes, &listing)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("reddit: %w", ctx.Err())
+			}
+			log.Printf("reddit: fetch r/%s: %v", sub, err)
+			lastErr = err
+			continue
+		}
+		for _, c := range listing.Data.Children {
+			p := c.Data
+			if c.Kind != "t3" || p.Stickied || p.Over18 || strings.TrimSpace(p.Title) == "" {
+				continue
+			}
+			link := p.URL
+			if p.IsSelf || link == "" {
+				link = redditBaseURL + p.Permalink
+			}
+			p.URL = link
+			if _, ok := subsOf[link]; !ok {
+				posts = append(posts, p)
+			}
+			subsOf[link] = append(subsOf[link], p.Subreddit)
+		}
+	}
+	if len(posts) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	titles := make([]string, len(posts))
+	for i, p := range posts {
+		titles[i] = strings.TrimSpace(p.Title)
+	}
+	translated := translateAllToChinese(ctx, r.Client, titles)
+
+	results := make([]NewsItem, 0, len(posts))
+	for i, p := range posts {
+		desc, origDesc := translated[i], titles[i]
+		if p.IsSelf && strings.TrimSpace(p.Selftext) != "" {
+			selftext := "\n\n" + truncateFeedRunes(strings.Join(strings.Fields(p.Selftext), " "), 300)
+			desc += selftext
+			origDesc += selftext
+		}
+		results = append(results, NewsItem{
+			Title:               translated[i],
+			URL:                 p.URL,
+			Source:              "reddit",
+			Description:         desc,
+			OriginalTitle:       untranslated(titles[i], translated[i]),
+			OriginalDescription: untranslated(origDesc, desc),
+			PublishedAt:         time.Unix(int64(p.CreatedUTC), 0),
+			HotScore:            float64(p.Score),
+			RawData: map[string]any{
+				"reddit_id":  p.ID,
+				"author":     p.Author,
+				"subreddit":  p.Subreddit,
+				"subreddits": subsOf[p.URL],
+				"score":      p.Score,
+				"comments":   p.NumComments,
+				"permalink":  redditBaseURL + p.Permalink,
+			},
+		})
+	}
+	if len(results) == 0 {
+		log.Println("reddit: no items fetched")
+	}
+	return results, nil
+}
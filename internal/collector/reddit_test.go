@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRedditFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/r/programming/hot.json?raw_json=1": "reddit_programming.json",
+		"/r/golang/hot.json?raw_json=1":      "reddit_golang.json",
+		"/translate_a/single":                "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	// technology 没有录制数据（404），只记日志不影响其它版块
+	f := &RedditFetcher{Subreddits: []string{"programming", "r/golang", "technology"}, Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "reddit", srv, items)
+}
+
+func TestRedditSubreddits(t *testing.T) {
+	f := &RedditFetcher{Subreddits: []string{" golang ", "r/Golang", "bad name", "x", "rust"}}
+	if got, want := f.subreddits(), []string{"golang", "rust"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("subreddits = %v, want %v", got, want)
+	}
+	f = &RedditFetcher{Subreddits: []string{"../etc"}}
+	if got := f.subreddits(); !reflect.DeepEqual(got, RedditDefaultSubreddits) {
+		t.Fatalf("subreddits = %v, want defaults", got)
+	}
+}
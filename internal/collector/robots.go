@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+const (
+	// RobotsUserAgent 匹配 robots.txt 规则时使用的爬虫名称，未单独声明时按 "*" 分组处理
+	RobotsUserAgent     = "TrendingHub"
+	robotsMaxBodyBytes  = 512 << 10 // 512KB
+	robotsCacheTTL      = 24 * time.Hour
+	robotsErrorCacheTTL = time.Hour
+	robotsTimeout       = 10 * time.Second
+)
+
+// RobotsChecker 按站点缓存 robots.txt，判断链接是否允许抓取。零值可用
+type RobotsChecker struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时只允许连接公网地址
+	Client *http.Client
+
+	mu    sync.Mutex
+	hosts map[string]robotsEntry
+}
+
+type robotsEntry struct {
+	data    *robotstxt.RobotsData
+	err     error
+	expires time.Time
+}
+
+// ErrRobotsUnavailable robots.txt 暂时无法获取（5xx 或网络错误），无法判断是否允许抓取，调用方应稍后重试
+var ErrRobotsUnavailable = errors.New("robots.txt unavailable")
+
+// Allowed 判断 rawURL 是否允许抓取：robots.txt 返回 4xx 视为全部允许；5xx 或网络错误时返回 ErrRobotsUnavailable
+// （缓存 1 小时），ctx 结束时返回其错误。err 不为 nil 时 allowed 为 false，表示暂时不抓取而非 robots.txt 禁止
+func (r *RobotsChecker) Allowed(ctx context.Context, rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false, nil
+	}
+	site := u.Scheme + "://" + u.Host
+	now := time.Now()
+
+	r.mu.Lock()
+	e, ok := r.hosts[site]
+	r.mu.Unlock()
+	if !ok || now.After(e.expires) {
+		data, err := r.fetch(ctx, site)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		ttl := robotsCacheTTL
+		if err != nil {
+			data, ttl = nil, robotsErrorCacheTTL
+		}
+		e = robotsEntry{data: data, err: err, expires: now.Add(ttl)}
+		r.mu.Lock()
+		if r.hosts == nil {
+			r.hosts = make(map[string]robotsEntry)
+		}
+		r.hosts[site] = e
+		r.mu.Unlock()
+	}
+	if e.err != nil {
+		return false, e.err
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return e.data.TestAgent(path, RobotsUserAgent), nil
+}
+
+func (r *RobotsChecker) fetch(ctx context.Context, site string) (*robotstxt.RobotsData, error) {
+	ctx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	resp, err := publicClientOrDefault(r.Client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: fetch %s: %v", ErrRobotsUnavailable, site, err)
+	}
+	defer resp.Body.Close()
+	// 5xx 为站点的暂时故障，不能当作 robots.txt 禁止抓取
+	if resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("%w: %s status %d", ErrRobotsUnavailable, site, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, robotsMaxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %s: %v", ErrRobotsUnavailable, site, err)
+	}
+	data, err := robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrRobotsUnavailable, site, err)
+	}
+	return data, nil
+}
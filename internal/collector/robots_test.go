@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRobotsChecker(t *testing.T) {
+	var hits atomic.Int32
+	var userAgent atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		hits.Add(1)
+		userAgent.Store(r.UserAgent())
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\nDisallow: /search?\n\nUser-agent: TrendingHub\nDisallow: /no-bots/\n"))
+	}))
+	defer srv.Close()
+
+	r := &RobotsChecker{Client: srv.Client()}
+	cases := map[string]bool{
+		"/post/1":          true,
+		"/private/1":       true, // 专属分组覆盖 "*" 分组
+		"/no-bots/page":    false,
+		"/search?q=golang": true,
+	}
+	for path, want := range cases {
+		if got, err := r.Allowed(context.Background(), srv.URL+path); got != want || err != nil {
+			t.Errorf("Allowed(%s) = %v, %v, want %v", path, got, err, want)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1 (cached per site)", n)
+	}
+	// 抓取时的 UA 与匹配规则的爬虫名称一致
+	if ua, _ := userAgent.Load().(string); !strings.Contains(ua, RobotsUserAgent+"/") {
+		t.Errorf("robots.txt fetched with User-Agent %q, want one naming %s", ua, RobotsUserAgent)
+	}
+	if ok, err := r.Allowed(context.Background(), "ftp://example.com/file"); ok || err != nil {
+		t.Errorf("non-http url should be disallowed: %v, %v", ok, err)
+	}
+}
+
+func TestRobotsCheckerStatus(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	if ok, err := (&RobotsChecker{Client: srv.Client()}).Allowed(context.Background(), srv.URL+"/a"); !ok || err != nil {
+		t.Errorf("missing robots.txt should allow all: %v, %v", ok, err)
+	}
+	// 5xx 是暂时故障：不允许抓取，但返回 ErrRobotsUnavailable，与 robots.txt 明确禁止区分开
+	status = http.StatusServiceUnavailable
+	if ok, err := (&RobotsChecker{Client: srv.Client()}).Allowed(context.Background(), srv.URL+"/a"); ok || !errors.Is(err, ErrRobotsUnavailable) {
+		t.Errorf("robots.txt 5xx should be unavailable: %v, %v", ok, err)
+	}
+}
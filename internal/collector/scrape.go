@@ -0,0 +1,431 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+const (
+	scrapeMaxItems       = 100
+	scrapeMaxBodyBytes   = 5 << 20 // 5MB
+	scrapeRequestTimeout = 15 * time.Second
+)
+
+// 声明式抓取定义的取值
+const (
+	ScrapeFormatHTML = "html"
+	ScrapeFormatJSON = "json"
+
+	ScrapeScoreField    = "field"     // 取 fields.hotScore 的数值
+	ScrapeScoreRankDesc = "rank_desc" // 按位置近似热度：len(items) - idx，越靠前越大
+)
+
+// ScrapeSpec 声明式抓取定义：请求一个页面，按 CSS 选择器（html）或 JSONPath（json）选出条目列表，再按字段映射生成 NewsItem。
+//
+// Fields 的 key 为 title / url / description / hotScore / publishedAt，其它 key 原样写入 RawData。取值相对于列表中的单个条目：
+//   - html："选择器"取文本，"选择器@属性"取属性，"@属性"取条目自身属性，空串取条目自身文本
+//   - json：JSONPath（如 "word"、"$.info.title"），"$" 为条目自身
+type ScrapeSpec struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"` // GET（默认）/ POST
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"` // POST 请求体
+	Format  string            `json:"format,omitempty"`
+	// Extract 可选正则，取第一个分组作为待解析文档，用于页面内嵌 JSON（如百度热搜的 <!--s-data:...-->）
+	Extract string `json:"extract,omitempty"`
+	// List 条目列表：html 为 CSS 选择器，json 为 JSONPath；JSONPath 命中单个数组时取其元素
+	List   string            `json:"list"`
+	Fields map[string]string `json:"fields"`
+	// URLTemplate 可选，用 {字段名} 引用已提取的字段（值会做 URL 编码）生成链接，优先于 fields.url
+	URLTemplate string `json:"urlTemplate,omitempty"`
+	// SkipIf 可选，字段取值为真（非空且不为 false / 0）时跳过该条目，如置顶条目
+	SkipIf string `json:"skipIf,omitempty"`
+	// Score 热度计算方式：field / rank_desc；为空时有 fields.hotScore 用 field，否则用 rank_desc
+	Score    string `json:"score,omitempty"`
+	MaxItems int    `json:"maxItems,omitempty"`
+}
+
+var scrapeTemplateRe = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// ParseScrapeSpec 从渠道配置解析抓取定义并校验
+func ParseScrapeSpec(config map[string]any) (ScrapeSpec, error) {
+	var spec ScrapeSpec
+	bs, err := json.Marshal(config)
+	if err != nil {
+		return spec, err
+	}
+	if err := json.Unmarshal(bs, &spec); err != nil {
+		return spec, fmt.Errorf("scrape spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return spec, err
+	}
+	return spec, nil
+}
+
+// Validate 校验抓取定义，并补全默认的 method / format / score
+func (s *ScrapeSpec) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(s.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("scrape spec: invalid url %q", s.URL)
+	}
+	s.URL = u.String()
+
+	s.Method = strings.ToUpper(strings.TrimSpace(s.Method))
+	if s.Method == "" {
+		s.Method = http.MethodGet
+	}
+	if s.Method != http.MethodGet && s.Method != http.MethodPost {
+		return fmt.Errorf("scrape spec: method must be GET or POST")
+	}
+
+	s.Format = strings.ToLower(strings.TrimSpace(s.Format))
+	if s.Format == "" {
+		s.Format = ScrapeFormatHTML
+	}
+	if s.Format != ScrapeFormatHTML && s.Format != ScrapeFormatJSON {
+		return fmt.Errorf("scrape spec: format must be html or json")
+	}
+
+	if s.Extract != "" {
+		re, err := regexp.Compile(s.Extract)
+		if err != nil {
+			return fmt.Errorf("scrape spec: invalid extract: %w", err)
+		}
+		if re.NumSubexp() < 1 {
+			return errors.New("scrape spec: extract must have a capture group")
+		}
+	}
+
+	if strings.TrimSpace(s.List) == "" {
+		return errors.New("scrape spec: list is required")
+	}
+	if _, ok := s.Fields["title"]; !ok {
+		return errors.New("scrape spec: fields.title is required")
+	}
+	if _, ok := s.Fields["url"]; !ok && s.URLTemplate == "" {
+		return errors.New("scrape spec: fields.url or urlTemplate is required")
+	}
+	for _, m := range scrapeTemplateRe.FindAllStringSubmatch(s.URLTemplate, -1) {
+		if _, ok := s.Fields[m[1]]; !ok {
+			return fmt.Errorf("scrape spec: urlTemplate references unknown field %q", m[1])
+		}
+	}
+
+	exprs := []string{s.List, s.SkipIf}
+	for _, v := range s.Fields {
+		exprs = append(exprs, v)
+	}
+	for _, e := range exprs {
+		if err := s.checkExpr(e); err != nil {
+			return err
+		}
+	}
+
+	switch s.Score {
+	case "":
+		s.Score = ScrapeScoreRankDesc
+		if _, ok := s.Fields["hotScore"]; ok {
+			s.Score = ScrapeScoreField
+		}
+	case ScrapeScoreRankDesc:
+	case ScrapeScoreField:
+		if _, ok := s.Fields["hotScore"]; !ok {
+			return errors.New("scrape spec: score=field requires fields.hotScore")
+		}
+	default:
+		return fmt.Errorf("scrape spec: score must be field or rank_desc")
+	}
+
+	if s.MaxItems <= 0 || s.MaxItems > scrapeMaxItems {
+		s.MaxItems = scrapeMaxItems
+	}
+	return nil
+}
+
+// checkExpr 校验选择器 / JSONPath 能否解析
+func (s *ScrapeSpec) checkExpr(expr string) error {
+	if s.Format == ScrapeFormatJSON {
+		if _, err := parseJSONPath(expr); err != nil {
+			return fmt.Errorf("scrape spec: %w", err)
+		}
+		return nil
+	}
+	sel, _ := splitSelectorAttr(expr)
+	if sel == "" {
+		return nil
+	}
+	if _, err := cascadia.Compile(sel); err != nil {
+		return fmt.Errorf("scrape spec: invalid selector %q: %w", sel, err)
+	}
+	return nil
+}
+
+// splitSelectorAttr 拆分 "选择器@属性"
+func splitSelectorAttr(expr string) (string, string) {
+	expr = strings.TrimSpace(expr)
+	if i := strings.LastIndex(expr, "@"); i >= 0 {
+		return strings.TrimSpace(expr[:i]), strings.TrimSpace(expr[i+1:])
+	}
+	return expr, ""
+}
+
+// ScrapeFetcher 按 ScrapeSpec 执行抓取的通用采集器，由渠道配置实例化
+type ScrapeFetcher struct {
+	// Source 渠道 code，同时用作条目的 Source 与采集器名称后缀
+	Source string
+	Spec   ScrapeSpec
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用只允许连接公网地址的共享客户端
+	Client *http.Client
+}
+
+func (f *ScrapeFetcher) Name() string {
+	return "scrape_" + f.Source
+}
+
+func (f *ScrapeFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Printf("fetch scrape %s (%s)...", f.Source, f.Spec.URL)
+
+	ctx, cancel := context.WithTimeout(ctx, scrapeRequestTimeout)
+	defer cancel()
+	var reqBody io.Reader
+	if f.Spec.Method == http.MethodPost && f.Spec.Body != "" {
+		reqBody = strings.NewReader(f.Spec.Body)
+	}
+	method := f.Spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, f.Spec.URL, reqBody)
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("scrape: build request: %w", err))
+	}
+	req.Header.Set("User-Agent", browserUserAgent)
+	for k, v := range f.Spec.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := publicClientOrDefault(f.Client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape: fetch %s: %w", f.Spec.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("scrape", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, scrapeMaxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("scrape: read %s: %w", f.Spec.URL, err)
+	}
+	items, err := f.Spec.Parse(body, f.Source)
+	if err != nil {
+		return nil, Permanent(err)
+	}
+	return items, nil
+}
+
+// scrapeRecord 单个条目提取出的字段
+type scrapeRecord struct {
+	fields map[string]string
+	skip   bool
+}
+
+// Parse 按定义解析响应内容
+func (s *ScrapeSpec) Parse(body []byte, source string) ([]NewsItem, error) {
+	if s.Extract != "" {
+		m := regexp.MustCompile(s.Extract).FindSubmatch(body)
+		if len(m) < 2 {
+			return nil, errors.New("scrape: extract pattern not found")
+		}
+		body = m[1]
+	}
+
+	var records []scrapeRecord
+	var err error
+	if s.Format == ScrapeFormatJSON {
+		records, err = s.parseJSON(body)
+	} else {
+		records, err = s.parseHTML(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	base, _ := url.Parse(s.URL)
+	now := time.Now()
+	results := make([]NewsItem, 0, len(records))
+	seen := make(map[string]bool)
+	for idx, r := range records {
+		if r.skip {
+			continue
+		}
+		title := r.fields["title"]
+		link := r.fields["url"]
+		if s.URLTemplate != "" {
+			link = scrapeTemplateRe.ReplaceAllStringFunc(s.URLTemplate, func(m string) string {
+				return url.QueryEscape(r.fields[m[1:len(m)-1]])
+			})
+		}
+		link = resolveFeedLink(base, link)
+		if title == "" || link == "" || seen[link] {
+			continue
+		}
+		seen[link] = true
+
+		hot := float64(len(records) - idx)
+		if s.Score == ScrapeScoreField {
+			hot = parseScoreNumber(r.fields["hotScore"])
+		}
+		published := parseFeedTime(r.fields["publishedAt"])
+		if published.IsZero() {
+			published = now
+		}
+		raw := map[string]any{"rank": idx + 1}
+		for k, v := range r.fields {
+			switch k {
+			case "title", "url", "description", "hotScore", "publishedAt":
+			default:
+				if v != "" {
+					raw[k] = v
+				}
+			}
+		}
+		results = append(results, NewsItem{
+			Title:       title,
+			URL:         link,
+			Source:      source,
+			Description: r.fields["description"],
+			PublishedAt: published,
+			HotScore:    hot,
+			RawData:     raw,
+		})
+		if len(results) >= s.MaxItems {
+			break
+		}
+	}
+	return results, nil
+}
+
+func (s *ScrapeSpec) parseHTML(body []byte) ([]scrapeRecord, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("scrape: parse html: %w", err)
+	}
+	var records []scrapeRecord
+	doc.Find(s.List).Each(func(_ int, sel *goquery.Selection) {
+		r := scrapeRecord{fields: make(map[string]string, len(s.Fields))}
+		for k, expr := range s.Fields {
+			r.fields[k] = htmlFieldValue(sel, expr)
+		}
+		if s.SkipIf != "" {
+			r.skip = isTruthy(htmlFieldValue(sel, s.SkipIf))
+		}
+		records = append(records, r)
+	})
+	return records, nil
+}
+
+// htmlFieldValue 在条目内按 "选择器@属性" 取值
+func htmlFieldValue(item *goquery.Selection, expr string) string {
+	selector, attr := splitSelectorAttr(expr)
+	target := item
+	if selector != "" {
+		target = item.Find(selector).First()
+	}
+	if attr != "" {
+		v, _ := target.Attr(attr)
+		return strings.TrimSpace(v)
+	}
+	return strings.Join(strings.Fields(target.Text()), " ")
+}
+
+func (s *ScrapeSpec) parseJSON(body []byte) ([]scrapeRecord, error) {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("scrape: parse json: %w", err)
+	}
+	steps, err := parseJSONPath(s.List)
+	if err != nil {
+		return nil, err
+	}
+	nodes := evalJSONPath(doc, steps)
+	if len(nodes) == 1 {
+		if arr, ok := nodes[0].([]any); ok {
+			nodes = arr
+		}
+	}
+
+	fieldSteps := make(map[string][]jsonPathStep, len(s.Fields))
+	for k, expr := range s.Fields {
+		if fieldSteps[k], err = parseJSONPath(expr); err != nil {
+			return nil, err
+		}
+	}
+	var skipSteps []jsonPathStep
+	if s.SkipIf != "" {
+		if skipSteps, err = parseJSONPath(s.SkipIf); err != nil {
+			return nil, err
+		}
+	}
+
+	records := make([]scrapeRecord, 0, len(nodes))
+	for _, n := range nodes {
+		r := scrapeRecord{fields: make(map[string]string, len(s.Fields))}
+		for k, st := range fieldSteps {
+			if vals := evalJSONPath(n, st); len(vals) > 0 {
+				r.fields[k] = jsonScalarString(vals[0])
+			}
+		}
+		if skipSteps != nil {
+			if vals := evalJSONPath(n, skipSteps); len(vals) > 0 {
+				r.skip = isTruthy(jsonScalarString(vals[0]))
+			}
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func isTruthy(v string) bool {
+	v = strings.TrimSpace(strings.ToLower(v))
+	return v != "" && v != "false" && v != "0"
+}
+
+var scoreNumberRe = regexp.MustCompile(`[-+]?\d+(?:\.\d+)?`)
+
+// parseScoreNumber 从 "1,234"、"12.3万"、"4.5k" 这类文本中解析热度数值，解析不到时返回 0
+func parseScoreNumber(s string) float64 {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	loc := scoreNumberRe.FindStringIndex(s)
+	if loc == nil {
+		return 0
+	}
+	n, err := strconv.ParseFloat(s[loc[0]:loc[1]], 64)
+	if err != nil {
+		return 0
+	}
+	switch suffix := strings.TrimSpace(s[loc[1]:]); {
+	case strings.HasPrefix(suffix, "万"):
+		n *= 1e4
+	case strings.HasPrefix(suffix, "亿"):
+		n *= 1e8
+	case strings.HasPrefix(suffix, "k"), strings.HasPrefix(suffix, "K"):
+		n *= 1e3
+	case strings.HasPrefix(suffix, "m"), strings.HasPrefix(suffix, "M"):
+		n *= 1e6
+	}
+	return n
+}
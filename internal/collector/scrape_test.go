@@ -0,0 +1,160 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScrapeSpecHTMLFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/trending": "github_trending.html",
+	})
+	spec, err := ParseScrapeSpec(map[string]any{
+		"url":  srv.URL + "/trending",
+		"list": "article.Box-row",
+		"fields": map[string]any{
+			"title":       "h2 a",
+			"url":         "h2 a@href",
+			"description": "p",
+			"hotScore":    "a[href$='/stargazers']",
+			"language":    "span[itemprop='programmingLanguage']",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseScrapeSpec error: %v", err)
+	}
+	f := &ScrapeFetcher{Source: "gh_spec", Spec: spec, Client: srv.Client()}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "scrape_github", srv, items)
+}
+
+// 百度热搜用声明式定义表达，结果应与手写采集器一致（含 len(contents)-idx 的热度）
+func TestScrapeSpecMatchesBaiduFetcher(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "baidu_board.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := ParseScrapeSpec(map[string]any{
+		"url":     "https://top.baidu.com/board?tab=realtime",
+		"format":  "json",
+		"extract": `(?s)<!--s-data:(.*?)-->`,
+		"list":    "$.data.cards[0].content",
+		"fields":  map[string]any{"title": "word", "url": "rawUrl", "description": "desc"},
+		"skipIf":  "isTop",
+	})
+	if err != nil {
+		t.Fatalf("ParseScrapeSpec error: %v", err)
+	}
+	got, err := spec.Parse(body, "baidu")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	all, err := parseBaiduBoard(string(body))
+	if err != nil {
+		t.Fatalf("parseBaiduBoard error: %v", err)
+	}
+	// 手写采集器在 rawUrl 为空时回退到榜单地址，声明式定义直接丢弃这类条目
+	var want []NewsItem
+	for _, it := range all {
+		if it.URL != baiduBaseURL+baiduBoardPath {
+			want = append(want, it)
+		}
+	}
+	if len(got) == 0 || len(got) != len(want) {
+		t.Fatalf("got %d items, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Title != want[i].Title || got[i].URL != want[i].URL || got[i].HotScore != want[i].HotScore {
+			t.Errorf("item %d: got %q %q %v, want %q %q %v", i,
+				got[i].Title, got[i].URL, got[i].HotScore, want[i].Title, want[i].URL, want[i].HotScore)
+		}
+	}
+}
+
+func TestScrapeSpecURLTemplateAndScore(t *testing.T) {
+	spec, err := ParseScrapeSpec(map[string]any{
+		"url":         "https://example.com/api/hot",
+		"format":      "json",
+		"list":        "$.items[*]",
+		"fields":      map[string]any{"title": "$.name", "query": "name", "hotScore": "stats['heat']"},
+		"urlTemplate": "https://example.com/search?q={query}",
+	})
+	if err != nil {
+		t.Fatalf("ParseScrapeSpec error: %v", err)
+	}
+	body := []byte(`{"items":[{"name":"a b","stats":{"heat":"12.5万"}},{"name":"","stats":{"heat":"1"}},{"name":"c","stats":{"heat":"1,024"}}]}`)
+	items, err := spec.Parse(body, "hot")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].URL != "https://example.com/search?q=a+b" || items[0].HotScore != 125000 {
+		t.Errorf("unexpected first item: %q %v", items[0].URL, items[0].HotScore)
+	}
+	if items[1].HotScore != 1024 || items[1].RawData["query"] != "c" {
+		t.Errorf("unexpected second item: %v %v", items[1].HotScore, items[1].RawData)
+	}
+}
+
+func TestScrapeSpecValidate(t *testing.T) {
+	cases := map[string]map[string]any{
+		"bad url":        {"url": "ftp://example.com", "list": "li", "fields": map[string]any{"title": "", "url": "a@href"}},
+		"no list":        {"url": "https://example.com", "fields": map[string]any{"title": "", "url": "a@href"}},
+		"no url field":   {"url": "https://example.com", "list": "li", "fields": map[string]any{"title": ""}},
+		"bad selector":   {"url": "https://example.com", "list": "li[", "fields": map[string]any{"title": "", "url": "a@href"}},
+		"bad jsonpath":   {"url": "https://example.com", "format": "json", "list": "items[x]", "fields": map[string]any{"title": "t", "url": "u"}},
+		"bad template":   {"url": "https://example.com", "list": "li", "fields": map[string]any{"title": ""}, "urlTemplate": "https://x/{id}"},
+		"bad extract":    {"url": "https://example.com", "extract": "no-group", "list": "li", "fields": map[string]any{"title": "", "url": "a@href"}},
+		"bad score":      {"url": "https://example.com", "list": "li", "fields": map[string]any{"title": "", "url": "a@href"}, "score": "field"},
+		"unknown format": {"url": "https://example.com", "format": "xml", "list": "li", "fields": map[string]any{"title": "", "url": "a@href"}},
+	}
+	for name, cfg := range cases {
+		if _, err := ParseScrapeSpec(cfg); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]any{
+		"data": map[string]any{
+			"list": []any{
+				map[string]any{"id": 1.0, "tags": []any{"a", "b"}},
+				map[string]any{"id": 2.0, "tags": []any{"c"}},
+			},
+			"weird key": "x",
+		},
+	}
+	cases := map[string][]string{
+		"$.data.list[*].id":      {"1", "2"},
+		"data.list[-1].id":       {"2"},
+		"$.data.list[*].tags[0]": {"a", "c"},
+		"$['data']['weird key']": {"x"},
+		"$.data.missing":         nil,
+		"$.data.list[5]":         nil,
+	}
+	for path, want := range cases {
+		steps, err := parseJSONPath(path)
+		if err != nil {
+			t.Fatalf("parseJSONPath(%q) error: %v", path, err)
+		}
+		nodes := evalJSONPath(doc, steps)
+		if len(nodes) != len(want) {
+			t.Errorf("%s: got %v, want %v", path, nodes, want)
+			continue
+		}
+		for i := range want {
+			if got := jsonScalarString(nodes[i]); got != want[i] {
+				t.Errorf("%s[%d]: got %q, want %q", path, i, got, want[i])
+			}
+		}
+	}
+}
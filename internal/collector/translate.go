@@ -1,22 +1,33 @@
 package collector
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
 
 const translateMaxResponseBytes = 256 * 1024
 
+// 翻译接口地址，测试中可替换为本地 httptest 服务
+var (
+	googleTranslateURL   = "https://translate.googleapis.com/translate_a/single"
+	myMemoryTranslateURL = "https://api.mymemory.translated.net/get"
+)
+
 const (
-	translateMaxLen        = 500
-	translateClientTimeout = 20 * time.Second
+	translateMaxLen         = 500
+	translateRequestTimeout = 20 * time.Second
 )
 
 func isMostlyChinese(s string) bool {
@@ -54,6 +65,9 @@ func isCJK(r rune) bool {
 }
 
 func sourceLangForMyMemory(s string) string {
+	if isMostlyChinese(s) {
+		return "zh-CN"
+	}
 	for _, r := range s {
 		if r >= 0x3040 && r <= 0x309f || r >= 0x30a0 && r <= 0x30ff {
 			return "ja"
@@ -62,68 +76,197 @@ func sourceLangForMyMemory(s string) string {
 	return "en"
 }
 
-// translateToChinese 依次尝试 Google Translate 直接 API → MyMemory，均失败则返回原文
-func translateToChinese(text string) string {
+// Translator 翻译服务提供方。target 为 NormalizeLang 规范后的目标语言（"zh" 为简体中文），各提供方自行映射为接口所需的语言代码；
+// 返回空串或错误时由翻译链尝试下一个提供方
+type Translator interface {
+	Name() string
+	Translate(ctx context.Context, client *http.Client, text, target string) (string, error)
+}
+
+// TranslationCache 翻译结果缓存，key 为目标语言与原文的哈希，见 translationCacheKey
+type TranslationCache interface {
+	GetTranslation(ctx context.Context, key string) (string, bool)
+	SaveTranslation(ctx context.Context, key, provider, text string)
+}
+
+// defaultTranslators 未配置时的翻译链：Google Translate 直接 API → MyMemory
+var defaultTranslators = []Translator{googleTranslator{}, myMemoryTranslator{}}
+
+var (
+	translationMu    sync.RWMutex
+	translators      = defaultTranslators
+	translationCache TranslationCache
+)
+
+// SetTranslators 设置按顺序回退的翻译链，为空时恢复默认（Google → MyMemory）；应在采集开始前调用
+func SetTranslators(ts []Translator) {
+	translationMu.Lock()
+	defer translationMu.Unlock()
+	if len(ts) == 0 {
+		ts = defaultTranslators
+	}
+	translators = ts
+}
+
+// SetTranslationCache 设置翻译缓存，nil 表示不缓存
+func SetTranslationCache(c TranslationCache) {
+	translationMu.Lock()
+	defer translationMu.Unlock()
+	translationCache = c
+}
+
+func currentTranslation() ([]Translator, TranslationCache) {
+	translationMu.RLock()
+	defer translationMu.RUnlock()
+	return translators, translationCache
+}
+
+// translationCacheKey 目标语言 + 原文的 SHA-256，原文先按 translateMaxLen 截断，与实际送翻的文本一致
+func translationCacheKey(target, text string) string {
+	sum := sha256.Sum256([]byte(target + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// untranslated 译文与原文不同时返回原文，否则返回空串；用于填写 NewsItem 的 Original* 字段
+func untranslated(original, translated string) string {
+	if original = strings.TrimSpace(original); original == strings.TrimSpace(translated) {
+		return ""
+	}
+	return original
+}
+
+// langCodeRe 语言代码形如 en、zh-CN、pt-BR
+var langCodeRe = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]{2,4})?$`)
+
+// NormalizeLang 将语言代码规范为小写，简体中文的各种写法（zh-CN、zh-Hans、cn）统一为 zh；非法代码返回 false
+func NormalizeLang(lang string) (string, bool) {
+	lang = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+	switch lang {
+	case "zh-cn", "zh-hans", "zh-sg", "cn":
+		return "zh", true
+	}
+	if !langCodeRe.MatchString(lang) {
+		return "", false
+	}
+	return lang, true
+}
+
+// TranslateText 将文本翻译为 target 语言：先查翻译缓存，未命中时依次尝试翻译链中的提供方，成功结果写回缓存；
+// 目标为中文且原文已是中文时直接返回。均失败或 ctx 已取消则返回原文（超过 translateMaxLen 的部分会被截断）。
+// client 为调用方使用的 HTTP 客户端，为空时使用共享客户端。
+func TranslateText(ctx context.Context, client *http.Client, text, target string) string {
+	client = httpClientOrDefault(client)
 	text = strings.TrimSpace(text)
-	if text == "" {
+	if text == "" || target == "zh" && isMostlyChinese(text) {
 		return text
 	}
 	if rs := []rune(text); len(rs) > translateMaxLen {
 		text = string(rs[:translateMaxLen])
 	}
 
-	if out := translateViaGoogle(text); out != "" {
-		return out
+	chain, cache := currentTranslation()
+	key := translationCacheKey(target, text)
+	if cache != nil {
+		if out, ok := cache.GetTranslation(ctx, key); ok {
+			return out
+		}
 	}
+	for _, t := range chain {
+		if ctx.Err() != nil {
+			return text
+		}
+		out, err := t.Translate(ctx, client, text, target)
+		if err != nil {
+			log.Printf("translate (%s): %v", t.Name(), err)
+			continue
+		}
+		if out = strings.TrimSpace(out); out != "" {
+			if cache != nil {
+				cache.SaveTranslation(ctx, key, t.Name(), out)
+			}
+			return out
+		}
+	}
+	return text
+}
+
+// translateToChinese 将文本翻译为简体中文，见 TranslateText
+func translateToChinese(ctx context.Context, client *http.Client, text string) string {
+	return TranslateText(ctx, client, text, "zh")
+}
+
+// translateConcurrency 批量翻译时的并发数，避免触发公共翻译接口的限流
+const translateConcurrency = 3
 
-	if out := translateViaMyMemory(text); out != "" {
-		return out
+// TranslateAll 并发翻译一组文本到 target 语言：空文本原样返回，目标为中文时已是中文的文本也原样返回；
+// ctx 取消后剩余文本保留原文
+func TranslateAll(ctx context.Context, client *http.Client, texts []string, target string) []string {
+	out := make([]string, len(texts))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, translateConcurrency)
+	for i, text := range texts {
+		out[i] = text
+		if strings.TrimSpace(text) == "" || target == "zh" && isMostlyChinese(text) {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, text string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if ctx.Err() == nil {
+				out[i] = TranslateText(ctx, client, text, target)
+			}
+		}(i, text)
 	}
+	wg.Wait()
+	return out
+}
 
-	return text
+// translateAllToChinese 并发翻译一组文本为简体中文，见 TranslateAll
+func translateAllToChinese(ctx context.Context, client *http.Client, texts []string) []string {
+	return TranslateAll(ctx, client, texts, "zh")
 }
 
-// translateViaGoogle 使用 Google Translate 公开 API（client=gtx，无需 TKK/密钥）
-func translateViaGoogle(text string) string {
+// googleTranslator 使用 Google Translate 公开 API（client=gtx，无需 TKK/密钥）
+type googleTranslator struct{}
+
+func (googleTranslator) Name() string { return "google" }
+
+func (googleTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, translateRequestTimeout)
+	defer cancel()
+
 	apiURL := fmt.Sprintf(
-		"https://translate.googleapis.com/translate_a/single?client=gtx&sl=auto&tl=zh-CN&dt=t&q=%s",
+		"%s?client=gtx&sl=auto&tl=%s&dt=t&q=%s",
+		googleTranslateURL,
+		googleLangCode(target),
 		url.QueryEscape(text),
 	)
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return ""
+		return "", err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0")
 
-	client := &http.Client{Timeout: translateClientTimeout}
-	resp, err := client.Do(req)
+	body, err := doTranslateRequest(client, req)
 	if err != nil {
-		log.Printf("translate (google-gtx): %v", err)
-		return ""
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("translate (google-gtx): status %d", resp.StatusCode)
-		return ""
-	}
-
-	body, err := io.ReadAll(io.LimitReader(resp.Body, translateMaxResponseBytes))
-	if err != nil {
-		return ""
+		return "", err
 	}
 
 	// 响应格式: [[["翻译文本","原文",...],...],...]
 	var raw []any
 	if err := json.Unmarshal(body, &raw); err != nil {
-		log.Printf("translate (google-gtx): decode error: %v", err)
-		return ""
+		return "", fmt.Errorf("decode: %w", err)
 	}
 
+	if len(raw) == 0 {
+		return "", nil
+	}
 	var result strings.Builder
 	outer, ok := raw[0].([]any)
 	if !ok {
-		return ""
+		return "", nil
 	}
 	for _, seg := range outer {
 		pair, ok := seg.([]any)
@@ -135,33 +278,58 @@ func translateViaGoogle(text string) string {
 		}
 	}
 
-	return strings.TrimSpace(result.String())
+	return strings.TrimSpace(result.String()), nil
 }
 
-func translateViaMyMemory(text string) string {
-	apiURL := "https://api.mymemory.translated.net/get?langpair=" + sourceLangForMyMemory(text) + "|zh&q=" + url.QueryEscape(text)
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+// myMemoryTranslator 使用 MyMemory 免费接口，源语言按文本粗略判断（日文假名为 ja，否则 en）
+type myMemoryTranslator struct{}
+
+func (myMemoryTranslator) Name() string { return "mymemory" }
+
+func (myMemoryTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, translateRequestTimeout)
+	defer cancel()
+
+	apiURL := myMemoryTranslateURL + "?langpair=" + sourceLangForMyMemory(text) + "|" + googleLangCode(target) + "&q=" + url.QueryEscape(text)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return ""
+		return "", err
 	}
-	client := &http.Client{Timeout: translateClientTimeout}
-	resp, err := client.Do(req)
+	body, err := doTranslateRequest(client, req)
 	if err != nil {
-		log.Printf("translate (mymemory): %v", err)
-		return ""
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("translate (mymemory): status %d", resp.StatusCode)
-		return ""
+		return "", err
 	}
 	var out struct {
 		ResponseData struct {
 			TranslatedText string `json:"translatedText"`
 		} `json:"responseData"`
 	}
-	if err := json.NewDecoder(io.LimitReader(resp.Body, translateMaxResponseBytes)).Decode(&out); err != nil {
-		return ""
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("decode: %w", err)
+	}
+	return strings.TrimSpace(out.ResponseData.TranslatedText), nil
+}
+
+// googleLangCode Google / MyMemory 接口的简体中文代码为 zh-CN
+func googleLangCode(target string) string {
+	if target == "zh" {
+		return "zh-CN"
+	}
+	if lang, region, ok := strings.Cut(target, "-"); ok {
+		return lang + "-" + strings.ToUpper(region)
+	}
+	return target
+}
+
+// doTranslateRequest 发送翻译请求并读取响应体，非 200 状态码视为失败
+func doTranslateRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
 	}
-	return strings.TrimSpace(out.ResponseData.TranslatedText)
+	return io.ReadAll(io.LimitReader(resp.Body, translateMaxResponseBytes))
 }
@@ -0,0 +1,219 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TranslatorConfig 可选翻译提供方所需的配置，未用到的提供方可留空
+type TranslatorConfig struct {
+	// DeepLAPIKey DeepL 的 Auth Key；以 ":fx" 结尾的免费版 Key 默认走 api-free.deepl.com
+	DeepLAPIKey string
+	// DeepLAPIURL 可选，覆盖 DeepL 翻译接口地址
+	DeepLAPIURL string
+	// LLMAPIURL OpenAI 兼容接口的 base URL（如 https://api.openai.com/v1），请求 <base>/chat/completions
+	LLMAPIURL string
+	LLMAPIKey string
+	LLMModel  string
+	// LibreTranslateURL 自建 LibreTranslate 服务地址，请求 <url>/translate
+	LibreTranslateURL    string
+	LibreTranslateAPIKey string
+}
+
+// NewTranslator 按名称创建翻译提供方：google、mymemory、deepl、openai、libretranslate；缺少必需配置时返回错误
+func NewTranslator(name string, cfg TranslatorConfig) (Translator, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "google":
+		return googleTranslator{}, nil
+	case "mymemory":
+		return myMemoryTranslator{}, nil
+	case "deepl":
+		if cfg.DeepLAPIKey == "" {
+			return nil, errors.New("deepl: DEEPL_API_KEY is required")
+		}
+		apiURL := cfg.DeepLAPIURL
+		if apiURL == "" {
+			apiURL = "https://api.deepl.com/v2/translate"
+			if strings.HasSuffix(cfg.DeepLAPIKey, ":fx") {
+				apiURL = "https://api-free.deepl.com/v2/translate"
+			}
+		}
+		return deepLTranslator{apiKey: cfg.DeepLAPIKey, apiURL: apiURL}, nil
+	case "openai":
+		if cfg.LLMAPIURL == "" || cfg.LLMModel == "" {
+			return nil, errors.New("openai: LLM_API_URL and LLM_MODEL are required")
+		}
+		return openAITranslator{baseURL: strings.TrimRight(cfg.LLMAPIURL, "/"), apiKey: cfg.LLMAPIKey, model: cfg.LLMModel}, nil
+	case "libretranslate":
+		if cfg.LibreTranslateURL == "" {
+			return nil, errors.New("libretranslate: LIBRETRANSLATE_URL is required")
+		}
+		return libreTranslator{baseURL: strings.TrimRight(cfg.LibreTranslateURL, "/"), apiKey: cfg.LibreTranslateAPIKey}, nil
+	default:
+		return nil, fmt.Errorf("unknown translator %q", name)
+	}
+}
+
+// deepLTranslator 使用 DeepL API v2
+type deepLTranslator struct {
+	apiKey string
+	apiURL string
+}
+
+func (deepLTranslator) Name() string { return "deepl" }
+
+func (d deepLTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, translateRequestTimeout)
+	defer cancel()
+
+	form := url.Values{}
+	form.Set("text", text)
+	form.Set("target_lang", deepLLangCode(target))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.apiKey)
+	body, err := doTranslateRequest(client, req)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("decode: %w", err)
+	}
+	if len(out.Translations) == 0 {
+		return "", nil
+	}
+	return out.Translations[0].Text, nil
+}
+
+// deepLLangCode DeepL 的目标语言代码为大写，简体中文为 ZH-HANS，英语与葡萄牙语需指定地区
+func deepLLangCode(target string) string {
+	switch target {
+	case "zh":
+		return "ZH-HANS"
+	case "en":
+		return "EN-US"
+	case "pt":
+		return "PT-BR"
+	}
+	return strings.ToUpper(target)
+}
+
+// openAITranslator 使用 OpenAI 兼容的 Chat Completions 接口翻译，适用于 OpenAI、DeepSeek、本地 Ollama 等
+type openAITranslator struct {
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func (openAITranslator) Name() string { return "openai" }
+
+func (o openAITranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, translateRequestTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]any{
+		"model":       o.model,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "system", "content": "Translate the user's text into " + languageName(target) + ". Output only the translation, without quotes or explanations."},
+			{"role": "user", "content": text},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+	body, err := doTranslateRequest(client, req)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("decode: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", nil
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+// languageNames 提示词中使用的常见语言名称
+var languageNames = map[string]string{
+	"zh": "Simplified Chinese", "zh-tw": "Traditional Chinese", "en": "English", "ja": "Japanese", "ko": "Korean",
+	"fr": "French", "de": "German", "es": "Spanish", "ru": "Russian", "pt": "Portuguese",
+}
+
+// languageName 提示词中使用的目标语言名称，未收录的语言使用语言代码
+func languageName(target string) string {
+	if name, ok := languageNames[target]; ok {
+		return name
+	}
+	return "the language with code " + target
+}
+
+// libreTranslator 使用自建的 LibreTranslate 服务
+type libreTranslator struct {
+	baseURL string
+	apiKey  string
+}
+
+func (libreTranslator) Name() string { return "libretranslate" }
+
+func (l libreTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, translateRequestTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]string{
+		"q":       text,
+		"source":  "auto",
+		"target":  target,
+		"format":  "text",
+		"api_key": l.apiKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.baseURL+"/translate", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doTranslateRequest(client, req)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("decode: %w", err)
+	}
+	return out.TranslatedText, nil
+}
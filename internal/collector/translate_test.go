@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stubTranslator 返回 out，out 中的 %s 替换为原文，便于检查译文对应的是哪段原文
+type stubTranslator struct {
+	name  string
+	out   string
+	err   error
+	calls int
+}
+
+func (s *stubTranslator) Name() string { return s.name }
+
+func (s *stubTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	s.calls++
+	return strings.ReplaceAll(s.out, "%s", text), s.err
+}
+
+type memTranslationCache struct {
+	mu    sync.Mutex
+	items map[string]string
+}
+
+func (m *memTranslationCache) GetTranslation(ctx context.Context, key string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.items[key]
+	return v, ok
+}
+
+func (m *memTranslationCache) SaveTranslation(ctx context.Context, key, provider, text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = text
+}
+
+func TestTranslateToChineseFallbackAndCache(t *testing.T) {
+	failing := &stubTranslator{name: "a", err: errors.New("boom")}
+	empty := &stubTranslator{name: "b"}
+	ok := &stubTranslator{name: "c", out: " 译文:%s "}
+	cache := &memTranslationCache{items: map[string]string{}}
+	oldChain, oldCache := currentTranslation()
+	SetTranslators([]Translator{failing, empty, ok})
+	SetTranslationCache(cache)
+	t.Cleanup(func() {
+		SetTranslators(oldChain)
+		SetTranslationCache(oldCache)
+	})
+
+	if got := translateToChinese(context.Background(), nil, "hello"); got != "译文:hello" {
+		t.Fatalf("translateToChinese = %q, want %q", got, "译文:hello")
+	}
+	if failing.calls != 1 || empty.calls != 1 || ok.calls != 1 {
+		t.Fatalf("calls = %d/%d/%d, want 1/1/1", failing.calls, empty.calls, ok.calls)
+	}
+	if got := translateToChinese(context.Background(), nil, "bye"); got != "译文:bye" {
+		t.Fatalf("translateToChinese = %q, want %q", got, "译文:bye")
+	}
+	// 再次翻译同一文本命中缓存，不再请求任何提供方，且不会拿到其它文本的译文
+	if got := translateToChinese(context.Background(), nil, "  hello "); got != "译文:hello" {
+		t.Fatalf("cached translateToChinese = %q, want %q", got, "译文:hello")
+	}
+	if ok.calls != 2 {
+		t.Fatalf("provider called %d times, want 2 with a cache hit", ok.calls)
+	}
+
+	// 全部失败时返回原文且不写缓存
+	SetTranslators([]Translator{failing})
+	if got := translateToChinese(context.Background(), nil, "world"); got != "world" {
+		t.Fatalf("translateToChinese = %q, want original", got)
+	}
+	if _, hit := cache.items[translationCacheKey("zh", "world")]; hit {
+		t.Fatal("failed translation should not be cached")
+	}
+}
+
+func TestTranslatorProviders(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = nil
+		switch r.URL.Path {
+		case "/v2/translate":
+			form, _ := url.ParseQuery(string(body))
+			gotBody = map[string]any{"target_lang": form.Get("target_lang"), "text": form.Get("text")}
+			_, _ = io.WriteString(w, `{"translations":[{"detected_source_language":"EN","text":"深度"}]}`)
+		case "/v1/chat/completions":
+			_ = json.Unmarshal(body, &gotBody)
+			_, _ = io.WriteString(w, `{"choices":[{"message":{"role":"assistant","content":"模型"}}]}`)
+		case "/translate":
+			_ = json.Unmarshal(body, &gotBody)
+			_, _ = io.WriteString(w, `{"translatedText":"自建"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := TranslatorConfig{
+		DeepLAPIKey:       "key:fx",
+		DeepLAPIURL:       srv.URL + "/v2/translate",
+		LLMAPIURL:         srv.URL + "/v1/",
+		LLMAPIKey:         "sk-test",
+		LLMModel:          "gpt-test",
+		LibreTranslateURL: srv.URL,
+	}
+	cases := []struct {
+		name, path, auth, want string
+		check                  func(map[string]any) bool
+	}{
+		{"deepl", "/v2/translate", "DeepL-Auth-Key key:fx", "深度", func(b map[string]any) bool {
+			return b["target_lang"] == "ZH-HANS" && b["text"] == "hello"
+		}},
+		{"openai", "/v1/chat/completions", "Bearer sk-test", "模型", func(b map[string]any) bool {
+			msgs, _ := b["messages"].([]any)
+			return b["model"] == "gpt-test" && len(msgs) == 2
+		}},
+		{"libretranslate", "/translate", "", "自建", func(b map[string]any) bool {
+			return b["target"] == "zh" && b["q"] == "hello"
+		}},
+	}
+	for _, c := range cases {
+		tr, err := NewTranslator(c.name, cfg)
+		if err != nil {
+			t.Fatalf("NewTranslator(%s): %v", c.name, err)
+		}
+		got, err := tr.Translate(context.Background(), srv.Client(), "hello", "zh")
+		if err != nil || got != c.want {
+			t.Fatalf("%s Translate = %q, %v; want %q", c.name, got, err, c.want)
+		}
+		if gotPath != c.path || gotAuth != c.auth || !c.check(gotBody) {
+			t.Fatalf("%s request = %s auth %q body %v", c.name, gotPath, gotAuth, gotBody)
+		}
+	}
+
+	if _, err := NewTranslator("deepl", TranslatorConfig{}); err == nil {
+		t.Fatal("deepl without key should fail")
+	}
+	if _, err := NewTranslator("bing", cfg); err == nil {
+		t.Fatal("unknown translator should fail")
+	}
+}
+
+func TestNormalizeLang(t *testing.T) {
+	cases := map[string]string{"zh-CN": "zh", "zh_Hans": "zh", "EN": "en", "pt-BR": "pt-br", "zh-TW": "zh-tw"}
+	for in, want := range cases {
+		if got, ok := NormalizeLang(in); !ok || got != want {
+			t.Errorf("NormalizeLang(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "english", "en us", "../x"} {
+		if _, ok := NormalizeLang(in); ok {
+			t.Errorf("NormalizeLang(%q) should fail", in)
+		}
+	}
+	if got := googleLangCode("zh-tw"); got != "zh-TW" {
+		t.Errorf("googleLangCode(zh-tw) = %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	v2exBaseURL          = "https://www.v2ex.com"
+	v2exHotPath          = "/api/topics/hot.json"
+	v2exMaxResponseBytes = 2 << 20 // 2MB
+	v2exRequestTimeout   = 10 * time.Second
+	v2exDescMaxRunes     = 300
+)
+
+// V2EXHotFetcher 抓取 V2EX 最热主题；非中文标题翻译为中文，回复数记录在 RawData.comments 中
+type V2EXHotFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 www.v2ex.com 站点地址，默认 v2exBaseURL
+	BaseURL string
+}
+
+func (v *V2EXHotFetcher) Name() string {
+	return "v2ex_hot"
+}
+
+type v2exTopic struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Content string `json:"content"`
+	Replies int    `json:"replies"`
+	Created int64  `json:"created"`
+	Member  struct {
+		Username string `json:"username"`
+	} `json:"member"`
+	Node struct {
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	} `json:"node"`
+}
+
+func (v *V2EXHotFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch V2EX hot topics...")
+
+	reqCtx, cancel := context.WithTimeout(ctx, v2exRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	var topics []v2exTopic
+	if err := getJSON(reqCtx, v.Client, "v2ex_hot", baseURLOrDefault(v.BaseURL, v2exBaseURL)+v2exHotPath, header, v2exMaxResponseBytes, &topics); err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, len(topics))
+	for i, t := range topics {
+		titles[i] = strings.TrimSpace(t.Title)
+	}
+	translated := translateAllToChinese(ctx, v.Client, titles)
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(topics))
+	for idx, t := range topics {
+		if titles[idx] == "" || t.URL == "" {
+			continue
+		}
+		desc := truncateFeedRunes(strings.Join(strings.Fields(t.Content), " "), v2exDescMaxRunes)
+		if desc == "" {
+			desc = translated[idx]
+		}
+		published := now
+		if t.Created > 0 {
+			published = time.Unix(t.Created, 0)
+		}
+		origDesc := ""
+		if desc == translated[idx] {
+			origDesc = untranslated(titles[idx], desc)
+		}
+		results = append(results, NewsItem{
+			Title:               translated[idx],
+			URL:                 t.URL,
+			Source:              "v2ex",
+			Description:         desc,
+			OriginalTitle:       untranslated(titles[idx], translated[idx]),
+			OriginalDescription: origDesc,
+			PublishedAt:         published,
+			HotScore:            float64(len(topics) - idx),
+			RawData: map[string]any{
+				"v2ex_id":  t.ID,
+				"author":   t.Member.Username,
+				"node":     t.Node.Title,
+				"comments": t.Replies,
+				"rank":     idx + 1,
+			},
+		})
+	}
+	if len(results) == 0 {
+		log.Println("v2ex_hot: no items fetched")
+	}
+	return results, nil
+}
@@ -0,0 +1,21 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestV2EXHotFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/api/topics/hot.json": "v2ex_hot.json",
+		"/translate_a/single":  "google_translate.json",
+	})
+	stubTranslation(t, srv)
+	f := &V2EXHotFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "v2ex_hot", srv, items)
+}
@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	weiboBaseURL          = "https://weibo.com"
+	weiboHotSearchPath    = "/ajax/side/hotSearch"
+	weiboSearchURL        = "https://s.weibo.com/weibo?q="
+	weiboMaxResponseBytes = 2 << 20 // 2MB
+	weiboRequestTimeout   = 10 * time.Second
+)
+
+// WeiboHotFetcher 抓取微博热搜榜，数据来自网页版侧栏接口 /ajax/side/hotSearch。
+// 广告位会被跳过；热度值（num）与标签（热 / 新 / 沸 / 爆）记录在 RawData 中。
+type WeiboHotFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 weibo.com 站点地址，默认 weiboBaseURL
+	BaseURL string
+}
+
+func (w *WeiboHotFetcher) Name() string {
+	return "weibo_hot"
+}
+
+type weiboHotResponse struct {
+	OK   int `json:"ok"`
+	Data struct {
+		Realtime []struct {
+			Word      string  `json:"word"`
+			Note      string  `json:"note"`
+			Num       float64 `json:"num"`
+			LabelName string  `json:"label_name"`
+			Category  string  `json:"category"`
+			IsAd      int     `json:"is_ad"`
+		} `json:"realtime"`
+	} `json:"data"`
+}
+
+func (w *WeiboHotFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Weibo Hot Search...")
+
+	ctx, cancel := context.WithTimeout(ctx, weiboRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	header.Set("Referer", "https://weibo.com/")
+	var resp weiboHotResponse
+	if err := getJSON(ctx, w.Client, "weibo_hot", baseURLOrDefault(w.BaseURL, weiboBaseURL)+weiboHotSearchPath, header, weiboMaxResponseBytes, &resp); err != nil {
+		return nil, err
+	}
+	if resp.OK != 1 {
+		return nil, fmt.Errorf("weibo_hot: unexpected ok=%d", resp.OK)
+	}
+
+	list := resp.Data.Realtime
+	now := time.Now()
+	results := make([]NewsItem, 0, len(list))
+	for idx, it := range list {
+		title := strings.TrimSpace(it.Word)
+		if title == "" || it.IsAd == 1 {
+			continue
+		}
+		desc := strings.TrimSpace(it.Note)
+		if desc == "" {
+			desc = title
+		}
+		raw := map[string]any{
+			"rank": idx + 1,
+			"heat": it.Num,
+		}
+		if it.LabelName != "" {
+			raw["label"] = it.LabelName
+		}
+		if it.Category != "" {
+			raw["category"] = it.Category
+		}
+		results = append(results, NewsItem{
+			Title:       title,
+			URL:         weiboSearchURL + url.QueryEscape("#"+title+"#"),
+			Source:      "weibo",
+			Description: desc,
+			PublishedAt: now,
+			HotScore:    float64(len(list) - idx),
+			RawData:     raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Printf("weibo_hot: no items parsed")
+	}
+	return results, nil
+}
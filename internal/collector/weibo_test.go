@@ -0,0 +1,19 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWeiboHotFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/ajax/side/hotSearch": "weibo_hot.json",
+	})
+	f := &WeiboHotFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "weibo_hot", srv, items)
+}
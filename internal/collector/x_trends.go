@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"io"
 	"log"
 	"net/http"
@@ -12,9 +13,16 @@ import (
 	"github.com/gocolly/colly/v2"
 )
 
-const xTrendsURL = "https://trends24.in/"
+const xTrendsURL = "https://trends24.in"
+const xGetdaytrendsURL = "https://getdaytrends.com"
 const xTrendsMaxItems = 50
 const xTrendsMaxBodyBytes = 2 << 20 // 2MB，防止超大 HTML 导致 DoS
+const xTrendsRequestTimeout = 15 * time.Second
+
+// XRegionWorldwide 全球榜，对应站点首页；其它地区使用站点路径中的 slug，如 japan、united-states
+const XRegionWorldwide = "worldwide"
+
+var xRegionSlugRe = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
 
 var (
 	xTrendsRe1      = regexp.MustCompile(`<a\s+[^>]*href="(https://twitter\.com/search\?q=[^"]+)"[^>]*>([^<]+)</a>`)
@@ -22,51 +30,124 @@ var (
 	xGetdaytrendsRe = regexp.MustCompile(`<a\s+href="https://getdaytrends\.com/trend/([^"]+)/?"[^>]*>([^<]+)</a>`)
 )
 
-// XTrendsFetcher 抓取 X (Twitter) 热搜，数据来自 trends24.in（全球榜）
-type XTrendsFetcher struct{}
+// XTrendsFetcher 抓取 X (Twitter) 热搜，数据来自 trends24.in，可同时抓取多个地区的榜单。
+// 同一话题出现在多个地区时合并为一条，地区信息记录在 RawData 的 region / regions / regionRanks 中。
+type XTrendsFetcher struct {
+	// Regions 要抓取的地区 slug（如 worldwide、china、japan、united-states），为空时只抓全球榜
+	Regions []string
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 trends24.in 站点地址，默认 xTrendsURL
+	BaseURL string
+	// GetdaytrendsURL 可选，覆盖备用源 getdaytrends.com 地址，默认 xGetdaytrendsURL
+	GetdaytrendsURL string
+}
 
 func (x *XTrendsFetcher) Name() string {
 	return "x_trends"
 }
 
-func (x *XTrendsFetcher) Fetch() ([]NewsItem, error) {
-	log.Println("fetch X (Twitter) trends...")
+func (x *XTrendsFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	regions := x.regions()
+	log.Printf("fetch X (Twitter) trends for %v...", regions)
 
-	list := x.fetchWithColly()
-	if len(list) == 0 {
-		list = x.fetchWithHTTP()
-	}
-	if len(list) == 0 {
-		list = x.fetchFromGetdaytrends()
+	// 按地区顺序合并，同一话题（URL）只保留一条；rank 取各地区中的最好名次
+	var results []NewsItem
+	index := make(map[string]int)
+	now := time.Now()
+	for _, region := range regions {
+		list := x.fetchRegion(ctx, region)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(list) > xTrendsMaxItems {
+			list = list[:xTrendsMaxItems]
+		}
+		for i, t := range list {
+			rank := i + 1
+			if idx, ok := index[t.url]; ok {
+				raw := results[idx].RawData
+				raw["regions"] = append(raw["regions"].([]string), region)
+				raw["regionRanks"].(map[string]int)[region] = rank
+				if rank < raw["rank"].(int) {
+					raw["rank"] = rank
+					results[idx].HotScore = xTrendsHotScore(rank)
+				}
+				continue
+			}
+			index[t.url] = len(results)
+			results = append(results, NewsItem{
+				Title:       t.title,
+				URL:         t.url,
+				Source:      "x",
+				Description: "X (Twitter) 热搜话题，点击在 X 上搜索。",
+				PublishedAt: now,
+				HotScore:    xTrendsHotScore(rank),
+				RawData: map[string]any{
+					"rank":        rank,
+					"region":      region,
+					"regions":     []string{region},
+					"regionRanks": map[string]int{region: rank},
+				},
+			})
+		}
 	}
 
-	if len(list) == 0 {
+	if len(results) == 0 {
 		log.Printf("fetch X trends got 0 items")
 		return nil, nil
 	}
+	return results, nil
+}
 
-	if len(list) > xTrendsMaxItems {
-		list = list[:xTrendsMaxItems]
+func xTrendsHotScore(rank int) float64 {
+	hotScore := float64(xTrendsMaxItems - rank + 1)
+	if hotScore < 1 {
+		hotScore = 1
 	}
+	return hotScore
+}
 
-	now := time.Now()
-	results := make([]NewsItem, 0, len(list))
-	for i, t := range list {
-		hotScore := float64(xTrendsMaxItems - i)
-		if hotScore < 1 {
-			hotScore = 1
+// regions 返回去重、校验后的地区列表；非法 slug 会被忽略
+func (x *XTrendsFetcher) regions() []string {
+	seen := make(map[string]bool, len(x.Regions))
+	var out []string
+	for _, r := range x.Regions {
+		r = strings.ToLower(strings.TrimSpace(r))
+		if !xRegionSlugRe.MatchString(r) || seen[r] {
+			continue
 		}
-		results = append(results, NewsItem{
-			Title:       t.title,
-			URL:         t.url,
-			Source:      "x",
-			Description: "X (Twitter) 热搜话题，点击在 X 上搜索。",
-			PublishedAt: now,
-			HotScore:    hotScore,
-			RawData:     map[string]any{"rank": i + 1},
-		})
+		seen[r] = true
+		out = append(out, r)
 	}
-	return results, nil
+	if len(out) == 0 {
+		return []string{XRegionWorldwide}
+	}
+	return out
+}
+
+// fetchRegion 抓取单个地区的榜单：trends24 (colly) → trends24 (正则) → getdaytrends 依次降级
+func (x *XTrendsFetcher) fetchRegion(ctx context.Context, region string) []xTrend {
+	path := xRegionPath(region)
+	list := x.fetchWithColly(ctx, path)
+	if len(list) == 0 && ctx.Err() == nil {
+		list = x.fetchWithHTTP(ctx, path)
+	}
+	if len(list) == 0 && ctx.Err() == nil {
+		list = x.fetchFromGetdaytrends(ctx, path)
+	}
+	if len(list) == 0 {
+		log.Printf("fetch X trends (%s) got 0 items", region)
+	}
+	return list
+}
+
+// xRegionPath 地区在 trends24 / getdaytrends 上的路径：全球榜为首页，其它为 /<slug>/
+func xRegionPath(region string) string {
+	if region == XRegionWorldwide {
+		return "/"
+	}
+	return "/" + region + "/"
 }
 
 type xTrend struct {
@@ -74,12 +155,13 @@ type xTrend struct {
 	url   string
 }
 
-func (x *XTrendsFetcher) fetchWithColly() []xTrend {
-	c := colly.NewCollector(
-		colly.AllowedDomains("trends24.in", "www.trends24.in"),
-		colly.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
+func (x *XTrendsFetcher) fetchWithColly(ctx context.Context, path string) []xTrend {
+	baseURL := baseURLOrDefault(x.BaseURL, xTrendsURL)
+	host := hostOf(baseURL)
+	c := newCollyCollector(ctx, x.Client, xTrendsRequestTimeout,
+		colly.AllowedDomains(host, "www."+host),
+		colly.UserAgent(browserUserAgent),
 	)
-	c.SetRequestTimeout(15 * time.Second)
 
 	var list []xTrend
 	seen := make(map[string]bool)
@@ -99,7 +181,7 @@ func (x *XTrendsFetcher) fetchWithColly() []xTrend {
 		list = append(list, xTrend{title: title, url: url})
 	})
 
-	if err := c.Visit(xTrendsURL); err != nil {
+	if err := c.Visit(baseURL + path); err != nil {
 		log.Printf("fetch X trends (colly): %v", err)
 		return nil
 	}
@@ -107,31 +189,23 @@ func (x *XTrendsFetcher) fetchWithColly() []xTrend {
 }
 
 // fetchWithHTTP 备用：直接 GET 后用正则从 HTML 中提取 trend 链接
-func (x *XTrendsFetcher) fetchWithHTTP() []xTrend {
-	body, err := x.httpGet(xTrendsURL)
-	if err != nil {
-		return nil
-	}
-	list := x.parseTrendLinks(body)
-	if len(list) > 0 {
-		return list
-	}
-	// 若全球榜无结果，尝试美国区
-	bodyUS, err := x.httpGet("https://trends24.in/united-states/")
+func (x *XTrendsFetcher) fetchWithHTTP(ctx context.Context, path string) []xTrend {
+	body, err := x.httpGet(ctx, baseURLOrDefault(x.BaseURL, xTrendsURL)+path)
 	if err != nil {
 		return nil
 	}
-	return x.parseTrendLinks(bodyUS)
+	return x.parseTrendLinks(body)
 }
 
-func (x *XTrendsFetcher) httpGet(url string) (string, error) {
-	client := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (x *XTrendsFetcher) httpGet(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, xTrendsRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	resp, err := client.Do(req)
+	req.Header.Set("User-Agent", browserUserAgent)
+	resp, err := httpClientOrDefault(x.Client).Do(req)
 	if err != nil {
 		log.Printf("fetch X trends (http): %v", err)
 		return "", err
@@ -198,9 +272,9 @@ func toXSearchURL(twitterSearchURL string) string {
 	return twitterSearchURL
 }
 
-// fetchFromGetdaytrends 备用：从 getdaytrends.com 解析全球榜，链接形如 /trend/话题名/
-func (x *XTrendsFetcher) fetchFromGetdaytrends() []xTrend {
-	body, err := x.httpGet("https://getdaytrends.com/")
+// fetchFromGetdaytrends 备用：从 getdaytrends.com 解析对应地区榜单，链接形如 /trend/话题名/
+func (x *XTrendsFetcher) fetchFromGetdaytrends(ctx context.Context, path string) []xTrend {
+	body, err := x.httpGet(ctx, baseURLOrDefault(x.GetdaytrendsURL, xGetdaytrendsURL)+path)
 	if err != nil {
 		return nil
 	}
@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"context"
+	"testing"
+)
+
+func TestXTrendsFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/": "trends24.html",
+	})
+	f := &XTrendsFetcher{Client: srv.Client(), BaseURL: srv.URL, GetdaytrendsURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "x_trends", srv, items)
+}
+
+func TestXTrendsFetcherMergesRegions(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/":       "trends24.html",
+		"/japan/": "trends24_japan.html",
+	})
+	f := &XTrendsFetcher{
+		Regions:         []string{"worldwide", "Japan", "japan", "../bad"},
+		Client:          srv.Client(),
+		BaseURL:         srv.URL,
+		GetdaytrendsURL: srv.URL,
+	}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "x_trends_regions", srv, items)
+}
+
+func TestXTrendsParseTrendLinks(t *testing.T) {
+	html := `<a href="https://twitter.com/search?q=%23Foo" class="trend-link">#Foo</a>` +
+		`<a href="https://twitter.com/search?q=%23Foo">#Foo</a>`
+	list := (&XTrendsFetcher{}).parseTrendLinks(html)
+	if len(list) != 1 {
+		t.Fatalf("expected 1 deduplicated trend, got %d (%v)", len(list), list)
+	}
+	if list[0].url != "https://x.com/search?q=%23Foo" {
+		t.Fatalf("unexpected url %q", list[0].url)
+	}
+}
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	zhihuBaseURL          = "https://www.zhihu.com"
+	zhihuHotListPath      = "/api/v3/feed/topstory/hot-lists/total?limit=50"
+	zhihuMaxResponseBytes = 4 << 20 // 4MB
+	zhihuRequestTimeout   = 10 * time.Second
+)
+
+// ZhihuHotFetcher 抓取知乎热榜。热度文案（如 "1234 万热度"）解析为数值记录在 RawData.heat 中
+type ZhihuHotFetcher struct {
+	// Client 可选，注入自定义 HTTP 客户端；为空时使用共享客户端
+	Client *http.Client
+	// BaseURL 可选，覆盖 www.zhihu.com 站点地址，默认 zhihuBaseURL
+	BaseURL string
+}
+
+func (z *ZhihuHotFetcher) Name() string {
+	return "zhihu_hot"
+}
+
+type zhihuHotResponse struct {
+	Data []struct {
+		DetailText string `json:"detail_text"`
+		Target     struct {
+			ID            int64  `json:"id"`
+			Title         string `json:"title"`
+			Excerpt       string `json:"excerpt"`
+			AnswerCount   int    `json:"answer_count"`
+			FollowerCount int    `json:"follower_count"`
+			Created       int64  `json:"created"`
+		} `json:"target"`
+	} `json:"data"`
+}
+
+func (z *ZhihuHotFetcher) FetchContext(ctx context.Context) ([]NewsItem, error) {
+	log.Println("fetch Zhihu Hot List...")
+
+	ctx, cancel := context.WithTimeout(ctx, zhihuRequestTimeout)
+	defer cancel()
+	header := http.Header{}
+	header.Set("User-Agent", browserUserAgent)
+	var resp zhihuHotResponse
+	if err := getJSON(ctx, z.Client, "zhihu_hot", baseURLOrDefault(z.BaseURL, zhihuBaseURL)+zhihuHotListPath, header, zhihuMaxResponseBytes, &resp); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]NewsItem, 0, len(resp.Data))
+	for idx, it := range resp.Data {
+		title := strings.TrimSpace(it.Target.Title)
+		if title == "" || it.Target.ID == 0 {
+			continue
+		}
+		desc := strings.TrimSpace(it.Target.Excerpt)
+		if desc == "" {
+			desc = title
+		}
+		raw := map[string]any{
+			"rank":      idx + 1,
+			"heat":      parseScoreNumber(it.DetailText),
+			"heatText":  strings.TrimSpace(it.DetailText),
+			"answers":   it.Target.AnswerCount,
+			"followers": it.Target.FollowerCount,
+		}
+		// 问题的创建时间可能早于上榜数日甚至数年，只记录在 RawData 中；发布时间与其它热榜一致，取上榜（采集）时间
+		if it.Target.Created > 0 {
+			raw["createdAt"] = time.Unix(it.Target.Created, 0).UTC().Format(time.RFC3339)
+		}
+		results = append(results, NewsItem{
+			Title:       title,
+			URL:         fmt.Sprintf("https://www.zhihu.com/question/%d", it.Target.ID),
+			Source:      "zhihu",
+			Description: desc,
+			PublishedAt: now,
+			HotScore:    float64(len(resp.Data) - idx),
+			RawData:     raw,
+		})
+	}
+	if len(results) == 0 {
+		log.Printf("zhihu_hot: no items parsed")
+	}
+	return results, nil
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestZhihuHotFetcherFixture(t *testing.T) {
+	srv := newFixtureServer(t, map[string]string{
+		"/api/v3/feed/topstory/hot-lists/total": "zhihu_hot.json",
+	})
+	f := &ZhihuHotFetcher{Client: srv.Client(), BaseURL: srv.URL}
+
+	items, err := f.FetchContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchContext error: %v", err)
+	}
+	assertGolden(t, "zhihu_hot", srv, items)
+	// 发布时间为上榜（采集）时间，而不是内容本身的创建时间
+	for _, it := range items {
+		if time.Since(it.PublishedAt) > time.Minute {
+			t.Fatalf("PublishedAt = %v, want the fetch time", it.PublishedAt)
+		}
+	}
+}
+
+func TestParseZhihuHeat(t *testing.T) {
+	cases := map[string]float64{
+		"1523 万热度": 15230000,
+		"98 万热度":   980000,
+		"3.5 亿热度":  350000000,
+		"":         0,
+	}
+	for in, want := range cases {
+		if got := parseScoreNumber(in); got != want {
+			t.Errorf("parseScoreNumber(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -17,6 +20,48 @@ type Config struct {
 	// 整站访问的 Basic Auth 账号与密码（为空则不开启）
 	BasicAuthUser string
 	BasicAuthPass string
+	// 管理接口（/api/v1/admin）的访问令牌，为空时管理接口不可用
+	AdminToken string
+	// 翻译提供方回退链（逗号分隔，可选 google、mymemory、deepl、openai、libretranslate），按顺序尝试
+	TranslateProviders []string
+	// 除默认中文外，采集时额外翻译的目标语言（逗号分隔，如 en,ja），可通过 /api/v1/news?lang= 获取
+	TranslateLangs []string
+	// DeepL API Key 与可选的接口地址
+	DeepLAPIKey string
+	DeepLAPIURL string
+	// OpenAI 兼容接口（base URL 形如 https://api.openai.com/v1）、密钥与模型
+	LLMAPIURL string
+	LLMAPIKey string
+	LLMModel  string
+	// 摘要：每分钟最多请求大模型的次数与单次采集最多新生成的摘要条数（使用上面的 OpenAI 兼容接口）
+	SummaryRatePerMinute int
+	SummaryMaxPerRun     int
+	// 自建 LibreTranslate 服务地址与可选密钥
+	LibreTranslateURL    string
+	LibreTranslateAPIKey string
+	// 链接预览：单次采集最多新抓取的链接数（仅对 config.enrich 为 true 的渠道生效）
+	EnrichMaxPerRun int
+	// 事件聚合：与多久内出现过的 story 比较标题
+	StoryWindow time.Duration
+	// 主题标签：自定义词典文件（JSON，合并到内置词典）；是否对词典未命中的条目调用大模型分类（使用上面的 OpenAI 兼容接口），以及单次采集最多分类的条数
+	TagDictFile          string
+	TagClassify          bool
+	TagClassifyMaxPerRun int
+	// Product Hunt API 的 Developer Token（为空则改用公开 Atom 订阅，无得票数）
+	ProductHuntToken string
+	// 采集重试：单次执行内对瞬时错误的最多尝试次数与指数退避区间
+	FetchRetryMaxAttempts    int
+	FetchRetryInitialBackoff time.Duration
+	FetchRetryMaxBackoff     time.Duration
+	// 采集熔断：连续失败多少次后暂停该数据源，以及暂停多久后探测恢复
+	FetchBreakerThreshold   int
+	FetchBreakerOpenTimeout time.Duration
+	// 同一任务 cron 执行重叠时的策略：skip（跳过本次）/ queue（排队等待）
+	FetchOverlapPolicy string
+	// 是否使用 Redis 租约保证多副本部署时同一数据源只有一个副本在采集
+	FetchDistributedLock bool
+	// 收到退出信号后等待进行中的请求与采集结束的最长时间，超时后强制取消
+	ShutdownTimeout time.Duration
 }
 
 func Load() *Config {
@@ -29,6 +74,34 @@ func Load() *Config {
 		QWeatherAPIKey:  getEnv("QWEATHER_API_KEY", ""),
 		BasicAuthUser:   getEnv("APP_BASIC_USER", ""),
 		BasicAuthPass:   getEnv("APP_BASIC_PASS", ""),
+		AdminToken:      getEnv("ADMIN_TOKEN", ""),
+
+		TranslateProviders:   getEnvList("TRANSLATE_PROVIDERS", []string{"google", "mymemory"}),
+		TranslateLangs:       getEnvList("TRANSLATE_LANGS", nil),
+		DeepLAPIKey:          getEnv("DEEPL_API_KEY", ""),
+		DeepLAPIURL:          getEnv("DEEPL_API_URL", ""),
+		LLMAPIURL:            getEnv("LLM_API_URL", ""),
+		LLMAPIKey:            getEnv("LLM_API_KEY", ""),
+		LLMModel:             getEnv("LLM_MODEL", ""),
+		SummaryRatePerMinute: getEnvInt("SUMMARY_RATE_PER_MIN", 10),
+		SummaryMaxPerRun:     getEnvInt("SUMMARY_MAX_PER_RUN", 10),
+		LibreTranslateURL:    getEnv("LIBRETRANSLATE_URL", ""),
+		LibreTranslateAPIKey: getEnv("LIBRETRANSLATE_API_KEY", ""),
+		EnrichMaxPerRun:      getEnvInt("ENRICH_MAX_PER_RUN", 20),
+		StoryWindow:          getEnvDuration("STORY_WINDOW", 72*time.Hour),
+		TagDictFile:          getEnv("TAG_DICT_FILE", ""),
+		TagClassify:          getEnvBool("TAG_CLASSIFY", false),
+		TagClassifyMaxPerRun: getEnvInt("TAG_CLASSIFY_MAX_PER_RUN", 40),
+		ProductHuntToken:     getEnv("PRODUCTHUNT_TOKEN", ""),
+
+		FetchRetryMaxAttempts:    getEnvInt("FETCH_RETRY_MAX_ATTEMPTS", 3),
+		FetchRetryInitialBackoff: getEnvDuration("FETCH_RETRY_INITIAL_BACKOFF", 2*time.Second),
+		FetchRetryMaxBackoff:     getEnvDuration("FETCH_RETRY_MAX_BACKOFF", 30*time.Second),
+		FetchBreakerThreshold:    getEnvInt("FETCH_BREAKER_THRESHOLD", 5),
+		FetchBreakerOpenTimeout:  getEnvDuration("FETCH_BREAKER_OPEN_TIMEOUT", 30*time.Minute),
+		FetchOverlapPolicy:       getEnv("FETCH_OVERLAP_POLICY", "skip"),
+		FetchDistributedLock:     getEnvBool("FETCH_DISTRIBUTED_LOCK", true),
+		ShutdownTimeout:          getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
 	}
 
 	log.Printf("config loaded: port=%s", cfg.AppPort)
@@ -41,3 +114,63 @@ func getEnv(key, def string) string {
 	}
 	return def
 }
+
+// getEnvList 读取逗号分隔的列表环境变量，忽略空项；未设置时返回默认值
+func getEnvList(key string, def []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	if len(out) == 0 {
+		return def
+	}
+	return out
+}
+
+// getEnvInt 读取整数环境变量，未设置或格式错误时返回默认值
+func getEnvInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("config: invalid %s=%q, use default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// getEnvDuration 读取时长环境变量（如 30s、5m），未设置或格式错误时返回默认值
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("config: invalid %s=%q, use default %v", key, v, def)
+		return def
+	}
+	return d
+}
+
+// getEnvBool 读取布尔环境变量（true/false/1/0 等），未设置或格式错误时返回默认值
+func getEnvBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("config: invalid %s=%q, use default %v", key, v, def)
+		return def
+	}
+	return b
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetEnvWithDefault(t *testing.T) {
@@ -43,3 +44,51 @@ func TestLoadReadsAuthAndPorts(t *testing.T) {
 	}
 }
 
+func TestGetEnvIntAndDuration(t *testing.T) {
+	const intKey, durKey = "TEST_FETCH_RETRY", "TEST_FETCH_BACKOFF"
+	defer func() {
+		_ = os.Unsetenv(intKey)
+		_ = os.Unsetenv(durKey)
+	}()
+
+	// 未设置时返回默认值
+	_ = os.Unsetenv(intKey)
+	_ = os.Unsetenv(durKey)
+	if got := getEnvInt(intKey, 3); got != 3 {
+		t.Fatalf("getEnvInt default = %d, want 3", got)
+	}
+	if got := getEnvDuration(durKey, 2*time.Second); got != 2*time.Second {
+		t.Fatalf("getEnvDuration default = %v, want 2s", got)
+	}
+
+	// 格式错误时回退默认值
+	_ = os.Setenv(intKey, "abc")
+	_ = os.Setenv(durKey, "soon")
+	if got := getEnvInt(intKey, 3); got != 3 {
+		t.Fatalf("getEnvInt invalid = %d, want 3", got)
+	}
+	if got := getEnvDuration(durKey, 2*time.Second); got != 2*time.Second {
+		t.Fatalf("getEnvDuration invalid = %v, want 2s", got)
+	}
+
+	_ = os.Setenv(intKey, "5")
+	_ = os.Setenv(durKey, "1m30s")
+	if got := getEnvInt(intKey, 3); got != 5 {
+		t.Fatalf("getEnvInt = %d, want 5", got)
+	}
+	if got := getEnvDuration(durKey, 2*time.Second); got != 90*time.Second {
+		t.Fatalf("getEnvDuration = %v, want 1m30s", got)
+	}
+}
+
+func TestGetEnvList(t *testing.T) {
+	const key = "TEST_TRANSLATE_PROVIDERS"
+	t.Setenv(key, "")
+	if got := getEnvList(key, []string{"google"}); len(got) != 1 || got[0] != "google" {
+		t.Fatalf("getEnvList default = %v", got)
+	}
+	t.Setenv(key, " deepl, ,google ")
+	if got := getEnvList(key, nil); len(got) != 2 || got[0] != "deepl" || got[1] != "google" {
+		t.Fatalf("getEnvList = %v, want [deepl google]", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Package enricher 抓取条目链接指向的页面，提取 OpenGraph / Twitter Card 元数据、canonical 链接与正文，作为链接预览写入 ExtraData
+package enricher
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+const (
+	defaultMaxPerRun   = 20
+	defaultConcurrency = 4
+	// MaxTextRunes 写入预览的正文上限，完整正文不入库
+	MaxTextRunes = 2000
+)
+
+// PreviewKey 链接预览在 ExtraData 中的字段名
+const PreviewKey = "preview"
+
+// Config 链接预览配置
+type Config struct {
+	// MaxPerRun 单次采集最多新抓取的链接数，其余条目留待下次采集，<=0 时为 20
+	MaxPerRun int
+	// Concurrency 同时抓取的页面数，<=0 时为 4
+	Concurrency int
+	// MaxBodyBytes 页面读取上限，<=0 时使用 collector.ArticleMaxBodyBytes
+	MaxBodyBytes int64
+	// Client 可选，注入自定义 HTTP 客户端（抓取页面与 robots.txt 共用）
+	Client *http.Client
+}
+
+// Cache 链接预览缓存，key 为条目 ID；GetPreviews 返回的 map 中存在 key 即表示已抓取过，值可能为空
+type Cache interface {
+	GetPreviews(ctx context.Context, ids []string) map[string]map[string]any
+	SavePreview(ctx context.Context, id, url string, preview map[string]any)
+}
+
+// Enricher 为条目补充链接预览：已抓取过的链接直接使用缓存，新链接在 robots.txt 允许时抓取一次
+type Enricher struct {
+	cfg    Config
+	cache  Cache
+	robots *collector.RobotsChecker
+}
+
+// New 创建链接预览服务
+func New(cfg Config, cache Cache) *Enricher {
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = defaultMaxPerRun
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+	return &Enricher{cfg: cfg, cache: cache, robots: &collector.RobotsChecker{Client: cfg.Client}}
+}
+
+// EnrichItems 将链接预览写入各条目的 RawData["preview"]。robots.txt 禁止或页面不可用（4xx、非 HTML）的链接记录后不再重试，
+// 瞬时错误只记日志、下次采集时重试；ctx 结束后剩余条目保持不变。返回带有预览的条目数
+func (e *Enricher) EnrichItems(ctx context.Context, items []processor.ProcessedNews) int {
+	if len(items) == 0 {
+		return 0
+	}
+	var cached map[string]map[string]any
+	if e.cache != nil {
+		ids := make([]string, len(items))
+		for i, it := range items {
+			ids[i] = it.ID
+		}
+		cached = e.cache.GetPreviews(ctx, ids)
+	}
+
+	var pending []int
+	for i := range items {
+		if data, ok := cached[items[i].ID]; ok {
+			setPreview(&items[i], data)
+			continue
+		}
+		if len(pending) < e.cfg.MaxPerRun {
+			pending = append(pending, i)
+		}
+	}
+
+	sem := make(chan struct{}, e.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for _, i := range pending {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return countPreviews(items)
+		}
+		wg.Add(1)
+		go func(it *processor.ProcessedNews) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			data, ok := e.fetch(ctx, it.URL)
+			if !ok {
+				return
+			}
+			if e.cache != nil {
+				e.cache.SavePreview(ctx, it.ID, it.URL, data)
+			}
+			setPreview(it, data)
+		}(&items[i])
+	}
+	wg.Wait()
+	return countPreviews(items)
+}
+
+func countPreviews(items []processor.ProcessedNews) int {
+	n := 0
+	for _, it := range items {
+		if it.RawData[PreviewKey] != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// fetch 抓取单个链接；ok 为 false 表示瞬时失败（含 robots.txt 暂时无法获取），不写入缓存
+func (e *Enricher) fetch(ctx context.Context, rawURL string) (map[string]any, bool) {
+	allowed, err := e.robots.Allowed(ctx, rawURL)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("enrich %s: %v", rawURL, err)
+		}
+		return nil, false
+	}
+	if !allowed {
+		return nil, true
+	}
+	a, err := collector.FetchArticle(ctx, e.cfg.Client, rawURL, e.cfg.MaxBodyBytes)
+	if err != nil {
+		if collector.IsPermanent(err) {
+			return nil, true
+		}
+		if ctx.Err() == nil {
+			log.Printf("enrich %s: %v", rawURL, err)
+		}
+		return nil, false
+	}
+	return previewData(a), true
+}
+
+// previewData 将提取结果转为 ExtraData 中的预览字段，空值省略
+func previewData(a collector.Article) map[string]any {
+	out := make(map[string]any, 6)
+	for k, v := range map[string]string{
+		"title":        a.Title,
+		"description":  a.Description,
+		"image":        a.Image,
+		"siteName":     a.SiteName,
+		"canonicalUrl": a.CanonicalURL,
+		"text":         processor.TruncateRunes(a.Text, MaxTextRunes),
+	} {
+		if v != "" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func setPreview(it *processor.ProcessedNews, data map[string]any) {
+	if len(data) == 0 {
+		return
+	}
+	if it.RawData == nil {
+		it.RawData = make(map[string]any)
+	}
+	it.RawData[PreviewKey] = data
+}
@@ -0,0 +1,173 @@
+package enricher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+type memoryCache struct {
+	mu sync.Mutex
+	m  map[string]map[string]any
+}
+
+func (c *memoryCache) GetPreviews(_ context.Context, ids []string) map[string]map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]map[string]any)
+	for _, id := range ids {
+		if v, ok := c.m[id]; ok {
+			out[id] = v
+		}
+	}
+	return out
+}
+
+func (c *memoryCache) SavePreview(_ context.Context, id, _ string, preview map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[id] = preview
+}
+
+const page = `<html><head>
+<title>fallback title</title>
+<meta property="og:title" content="A fast JSON parser">
+<meta property="og:site_name" content="GitHub">
+<meta property="og:image" content="https://opengraph.githubassets.com/1/acme/json">
+<meta name="twitter:description" content="Parse gigabytes of JSON per second.">
+<link rel="canonical" href="/acme/json">
+</head><body><article>
+<p>acme/json is a JSON parser that uses SIMD instructions to parse gigabytes of JSON per second on a single core.</p>
+</article></body></html>`
+
+func newServer(t *testing.T, hits map[string]int) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+		case "/acme/json", "/acme/other":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(page))
+		case "/flaky":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestEnrichItems(t *testing.T) {
+	hits := map[string]int{}
+	srv := newServer(t, hits)
+	cache := &memoryCache{m: map[string]map[string]any{"cached": {"title": "from cache"}}}
+	e := New(Config{Client: srv.Client()}, cache)
+
+	items := []processor.ProcessedNews{
+		{ID: "repo", URL: srv.URL + "/acme/json", RawData: map[string]any{"stars": 10}},
+		{ID: "cached", URL: srv.URL + "/cached"},
+		{ID: "private", URL: srv.URL + "/private/doc"},
+		{ID: "missing", URL: srv.URL + "/missing"},
+		{ID: "flaky", URL: srv.URL + "/flaky"},
+	}
+	e.EnrichItems(context.Background(), items)
+
+	preview, _ := items[0].RawData[PreviewKey].(map[string]any)
+	want := map[string]string{
+		"title":        "A fast JSON parser",
+		"description":  "Parse gigabytes of JSON per second.",
+		"image":        "https://opengraph.githubassets.com/1/acme/json",
+		"siteName":     "GitHub",
+		"canonicalUrl": srv.URL + "/acme/json",
+	}
+	for k, v := range want {
+		if preview[k] != v {
+			t.Errorf("preview[%s] = %v, want %q", k, preview[k], v)
+		}
+	}
+	if text, _ := preview["text"].(string); !strings.HasPrefix(text, "acme/json is a JSON parser") {
+		t.Errorf("preview text = %q", text)
+	}
+	if items[0].RawData["stars"] != 10 {
+		t.Error("existing raw data should be kept")
+	}
+	if p, _ := items[1].RawData[PreviewKey].(map[string]any); p["title"] != "from cache" {
+		t.Errorf("cached preview = %v", items[1].RawData)
+	}
+	for _, it := range items[2:] {
+		if it.RawData[PreviewKey] != nil {
+			t.Errorf("%s: unexpected preview %v", it.ID, it.RawData[PreviewKey])
+		}
+	}
+	if hits["/private/doc"] != 0 || hits["/cached"] != 0 {
+		t.Errorf("fetched disallowed or cached url: %v", hits)
+	}
+
+	// 页面不可用与 robots.txt 禁止的链接记录后不再抓取，瞬时错误下次重试
+	if _, ok := cache.m["private"]; !ok {
+		t.Error("robots-disallowed url should be recorded")
+	}
+	if _, ok := cache.m["missing"]; !ok {
+		t.Error("404 url should be recorded")
+	}
+	if _, ok := cache.m["flaky"]; ok {
+		t.Error("5xx url should not be recorded")
+	}
+	e.EnrichItems(context.Background(), items)
+	if hits["/acme/json"] != 1 || hits["/missing"] != 1 || hits["/flaky"] != 2 {
+		t.Errorf("hits after second run = %v", hits)
+	}
+}
+
+func TestEnrichItemsRobotsUnavailable(t *testing.T) {
+	var robotsStatus atomic.Int32
+	robotsStatus.Store(http.StatusServiceUnavailable)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(int(robotsStatus.Load()))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+	cache := &memoryCache{m: map[string]map[string]any{}}
+	items := []processor.ProcessedNews{{ID: "repo", URL: srv.URL + "/acme/json"}}
+
+	// robots.txt 暂时不可用：不抓取，也不记录，下次采集重试
+	New(Config{Client: srv.Client()}, cache).EnrichItems(context.Background(), items)
+	if _, ok := cache.m["repo"]; ok || items[0].RawData[PreviewKey] != nil {
+		t.Fatalf("robots outage should not be recorded: cache=%v", cache.m)
+	}
+	robotsStatus.Store(http.StatusNotFound)
+	if n := New(Config{Client: srv.Client()}, cache).EnrichItems(context.Background(), items); n != 1 {
+		t.Errorf("preview after robots recovered = %d, want 1", n)
+	}
+}
+
+func TestEnrichItemsMaxPerRun(t *testing.T) {
+	hits := map[string]int{}
+	srv := newServer(t, hits)
+	e := New(Config{Client: srv.Client(), MaxPerRun: 1}, nil)
+
+	items := []processor.ProcessedNews{
+		{ID: "a", URL: srv.URL + "/acme/json"},
+		{ID: "b", URL: srv.URL + "/acme/other"},
+	}
+	e.EnrichItems(context.Background(), items)
+	if items[0].RawData[PreviewKey] == nil || items[1].RawData[PreviewKey] != nil {
+		t.Errorf("want only the first item enriched: %v, %v", items[0].RawData, items[1].RawData)
+	}
+}
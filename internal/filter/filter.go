@@ -0,0 +1,226 @@
+// Package filter 按 filter_rules 表中的关键词 / 正则规则过滤入库前的条目，匹配时忽略大小写与全角 / 半角差异
+package filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+	"golang.org/x/text/unicode/norm"
+)
+
+// reloadInterval 规则缓存的有效期；管理接口修改规则后调用 Invalidate 立即生效，其它副本最迟该时间后生效
+const reloadInterval = time.Minute
+
+// Normalize 匹配前的统一处理：NFKC 归一（全角字母、数字与标点转半角）后转小写
+func Normalize(s string) string {
+	return strings.ToLower(norm.NFKC.String(s))
+}
+
+// Rule 编译后的过滤规则
+type Rule struct {
+	storage.FilterRule
+	keyword string
+	re      *regexp.Regexp
+}
+
+// Compile 校验并编译规则；Action / MatchType / Field 为空时分别按 exclude / keyword / title 处理
+func Compile(r storage.FilterRule) (*Rule, error) {
+	r.Channel = strings.TrimSpace(r.Channel)
+	r.Pattern = strings.TrimSpace(r.Pattern)
+	if r.Action == "" {
+		r.Action = storage.FilterExclude
+	}
+	if r.MatchType == "" {
+		r.MatchType = storage.FilterKeyword
+	}
+	if r.Field == "" {
+		r.Field = storage.FilterFieldTitle
+	}
+	if r.Action != storage.FilterInclude && r.Action != storage.FilterExclude {
+		return nil, errors.New("action must be include or exclude")
+	}
+	if r.Field != storage.FilterFieldTitle && r.Field != storage.FilterFieldAll {
+		return nil, errors.New("field must be title or all")
+	}
+	if r.Pattern == "" {
+		return nil, errors.New("pattern is required")
+	}
+	out := &Rule{FilterRule: r}
+	switch r.MatchType {
+	case storage.FilterKeyword:
+		out.keyword = Normalize(r.Pattern)
+	case storage.FilterRegex:
+		re, err := regexp.Compile("(?i)" + norm.NFKC.String(r.Pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		out.re = re
+	default:
+		return nil, errors.New("matchType must be keyword or regex")
+	}
+	return out, nil
+}
+
+// Match 判断条目是否命中规则；title 字段匹配标题与原标题，all 另外匹配介绍与原介绍
+func (r *Rule) Match(title, originalTitle, description, originalDescription string) bool {
+	texts := []string{title, originalTitle}
+	if r.Field == storage.FilterFieldAll {
+		texts = append(texts, description, originalDescription)
+	}
+	for _, t := range texts {
+		if t == "" {
+			continue
+		}
+		t = Normalize(t)
+		if r.re != nil && r.re.MatchString(t) || r.re == nil && strings.Contains(t, r.keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Drops 单独使用该规则时是否会去掉条目：exclude 规则去掉命中的条目，include 规则去掉未命中的条目
+func (r *Rule) Drops(title, originalTitle, description, originalDescription string) bool {
+	return r.Match(title, originalTitle, description, originalDescription) == (r.Action == storage.FilterExclude)
+}
+
+// RuleSet 对某个渠道生效的规则
+type RuleSet struct {
+	include []*Rule
+	exclude []*Rule
+}
+
+// Keep 判断条目是否保留：命中任一 exclude 规则即去掉；存在 include 规则时必须命中其中之一
+func (rs *RuleSet) Keep(it processor.ProcessedNews) bool {
+	for _, r := range rs.exclude {
+		if r.Match(it.Title, it.OriginalTitle, it.Description, it.OriginalDescription) {
+			return false
+		}
+	}
+	if len(rs.include) == 0 {
+		return true
+	}
+	for _, r := range rs.include {
+		if r.Match(it.Title, it.OriginalTitle, it.Description, it.OriginalDescription) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rs *RuleSet) add(r *Rule) {
+	if r.Action == storage.FilterInclude {
+		rs.include = append(rs.include, r)
+	} else {
+		rs.exclude = append(rs.exclude, r)
+	}
+}
+
+// Store 过滤规则的持久化，由 storage.Store 实现
+type Store interface {
+	ListFilterRules(channel string) ([]storage.FilterRule, error)
+}
+
+// Filter 过滤阶段：启用的规则按渠道编译后缓存
+type Filter struct {
+	store Store
+
+	mu        sync.Mutex
+	loadedAt  time.Time
+	global    []*Rule
+	byChannel map[string][]*Rule
+}
+
+// New 创建过滤阶段，规则在首次使用时加载
+func New(store Store) *Filter {
+	return &Filter{store: store}
+}
+
+// Invalidate 丢弃缓存的规则，下次过滤时重新加载
+func (f *Filter) Invalidate() {
+	f.mu.Lock()
+	f.loadedAt = time.Time{}
+	f.mu.Unlock()
+}
+
+// FilterItems 返回保留的条目；规则加载失败时不过滤，只记日志
+func (f *Filter) FilterItems(_ context.Context, items []processor.ProcessedNews) []processor.ProcessedNews {
+	sets, err := f.ruleSets(items)
+	if err != nil {
+		log.Printf("filter: load rules: %v", err)
+		return items
+	}
+	if len(sets) == 0 {
+		return items
+	}
+	out := items[:0]
+	for _, it := range items {
+		if rs, ok := sets[it.Source]; ok && !rs.Keep(it) {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// ruleSets 返回本批条目涉及的各渠道的规则（全局规则 + 渠道规则），没有规则的渠道不出现在结果中
+func (f *Filter) ruleSets(items []processor.ProcessedNews) (map[string]*RuleSet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Since(f.loadedAt) >= reloadInterval {
+		if err := f.load(); err != nil {
+			return nil, err
+		}
+	}
+	sets := make(map[string]*RuleSet)
+	for _, it := range items {
+		if _, ok := sets[it.Source]; ok {
+			continue
+		}
+		rules := append(append([]*Rule(nil), f.global...), f.byChannel[it.Source]...)
+		if len(rules) == 0 {
+			continue
+		}
+		rs := &RuleSet{}
+		for _, r := range rules {
+			rs.add(r)
+		}
+		sets[it.Source] = rs
+	}
+	return sets, nil
+}
+
+func (f *Filter) load() error {
+	list, err := f.store.ListFilterRules("")
+	if err != nil {
+		return err
+	}
+	f.global = nil
+	f.byChannel = make(map[string][]*Rule)
+	for _, r := range list {
+		if !r.Enabled {
+			continue
+		}
+		compiled, err := Compile(r)
+		if err != nil {
+			// 规则在保存前已校验，这里只可能是手工改库造成的，跳过即可
+			log.Printf("filter: skip rule %d: %v", r.ID, err)
+			continue
+		}
+		if compiled.Channel == "" {
+			f.global = append(f.global, compiled)
+		} else {
+			f.byChannel[compiled.Channel] = append(f.byChannel[compiled.Channel], compiled)
+		}
+	}
+	f.loadedAt = time.Now()
+	return nil
+}
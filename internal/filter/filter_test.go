@@ -0,0 +1,140 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+func TestCompile(t *testing.T) {
+	r, err := Compile(storage.FilterRule{Pattern: " 明星 "})
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+	if r.Action != storage.FilterExclude || r.MatchType != storage.FilterKeyword || r.Field != storage.FilterFieldTitle || r.Pattern != "明星" {
+		t.Errorf("defaults not applied: %+v", r.FilterRule)
+	}
+	for _, bad := range []storage.FilterRule{
+		{Pattern: ""},
+		{Pattern: "x", Action: "drop"},
+		{Pattern: "x", MatchType: "glob"},
+		{Pattern: "x", Field: "url"},
+		{Pattern: "(", MatchType: storage.FilterRegex},
+	} {
+		if _, err := Compile(bad); err == nil {
+			t.Errorf("Compile(%+v) should fail", bad)
+		}
+	}
+}
+
+func TestRuleMatchIgnoresCaseAndWidth(t *testing.T) {
+	cases := []struct {
+		rule  storage.FilterRule
+		title string
+		want  bool
+	}{
+		{storage.FilterRule{Pattern: "bitcoin"}, "ＢＩＴＣＯＩＮ 突破新高", true},
+		{storage.FilterRule{Pattern: "ＡＩ"}, "OpenAI 发布新模型", true},
+		{storage.FilterRule{Pattern: "恋情"}, "某明星恋情曝光", true},
+		{storage.FilterRule{Pattern: "恋情"}, "航天员出舱", false},
+		{storage.FilterRule{Pattern: `^\$[a-z]+$`, MatchType: storage.FilterRegex}, "＄DOGE", true},
+		{storage.FilterRule{Pattern: `(离婚|出轨)`, MatchType: storage.FilterRegex}, "某演员官宣离婚", true},
+		{storage.FilterRule{Pattern: `(离婚|出轨)`, MatchType: storage.FilterRegex}, "神舟二十号发射成功", false},
+	}
+	for _, c := range cases {
+		r, err := Compile(c.rule)
+		if err != nil {
+			t.Fatalf("Compile(%+v): %v", c.rule, err)
+		}
+		if got := r.Match(c.title, "", "", ""); got != c.want {
+			t.Errorf("rule %q on %q = %v, want %v", c.rule.Pattern, c.title, got, c.want)
+		}
+	}
+
+	// title 规则不匹配介绍，all 规则匹配
+	title, _ := Compile(storage.FilterRule{Pattern: "空投"})
+	all, _ := Compile(storage.FilterRule{Pattern: "空投", Field: storage.FilterFieldAll})
+	if title.Match("Trending", "", "免费空投", "") || !all.Match("Trending", "", "免费空投", "") {
+		t.Error("field should control whether description is matched")
+	}
+	// 翻译前的原标题也参与匹配
+	if !title.Match("热门话题", "空投 airdrop", "", "") {
+		t.Error("original title should be matched")
+	}
+}
+
+type memoryStore struct {
+	rules []storage.FilterRule
+	loads int
+}
+
+func (m *memoryStore) ListFilterRules(string) ([]storage.FilterRule, error) {
+	m.loads++
+	return m.rules, nil
+}
+
+func titles(items []processor.ProcessedNews) []string {
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i] = it.Title
+	}
+	return out
+}
+
+func TestFilterItems(t *testing.T) {
+	store := &memoryStore{rules: []storage.FilterRule{
+		{ID: 1, Channel: "baidu", Action: storage.FilterExclude, MatchType: storage.FilterKeyword, Pattern: "明星", Enabled: true},
+		{ID: 2, Channel: "x", Action: storage.FilterExclude, MatchType: storage.FilterRegex, Pattern: `crypto|\$[a-z]+`, Enabled: true},
+		{ID: 3, Channel: "hackernews", Action: storage.FilterInclude, MatchType: storage.FilterKeyword, Pattern: "go", Enabled: true},
+		{ID: 4, Channel: "hackernews", Action: storage.FilterExclude, MatchType: storage.FilterKeyword, Pattern: "google", Enabled: true},
+		{ID: 5, Action: storage.FilterExclude, MatchType: storage.FilterKeyword, Pattern: "广告", Enabled: true},
+		{ID: 6, Channel: "baidu", Action: storage.FilterExclude, MatchType: storage.FilterKeyword, Pattern: "天气", Enabled: false},
+	}}
+	f := New(store)
+	items := []processor.ProcessedNews{
+		{Source: "baidu", Title: "某明星官宣"},
+		{Source: "baidu", Title: "全国天气预报"},
+		{Source: "baidu", Title: "双十一广告"},
+		{Source: "x", Title: "#Crypto"},
+		{Source: "x", Title: "$PEPE"},
+		{Source: "x", Title: "#GoLang"},
+		{Source: "hackernews", Title: "Go 1.24 released"},
+		{Source: "hackernews", Title: "Google fires Go team"},
+		{Source: "hackernews", Title: "Rust 2024 edition"},
+		{Source: "weibo", Title: "明星恋情"},
+	}
+	got := titles(f.FilterItems(context.Background(), items))
+	want := []string{"全国天气预报", "#GoLang", "Go 1.24 released", "明星恋情"}
+	if len(got) != len(want) {
+		t.Fatalf("kept %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("kept %v, want %v", got, want)
+		}
+	}
+
+	// 规则缓存，Invalidate 后重新加载
+	f.FilterItems(context.Background(), []processor.ProcessedNews{{Source: "baidu", Title: "a"}})
+	if store.loads != 1 {
+		t.Errorf("rules loaded %d times, want 1", store.loads)
+	}
+	store.rules = nil
+	f.Invalidate()
+	if out := f.FilterItems(context.Background(), []processor.ProcessedNews{{Source: "baidu", Title: "某明星官宣"}}); len(out) != 1 || store.loads != 2 {
+		t.Errorf("after invalidate: kept %d, loads %d", len(out), store.loads)
+	}
+}
+
+func TestRuleDrops(t *testing.T) {
+	exclude, _ := Compile(storage.FilterRule{Pattern: "明星"})
+	include, _ := Compile(storage.FilterRule{Pattern: "go", Action: storage.FilterInclude})
+	if !exclude.Drops("明星八卦", "", "", "") || exclude.Drops("航天", "", "", "") {
+		t.Error("exclude rule should drop matching items only")
+	}
+	if include.Drops("Go 1.24", "", "", "") || !include.Drops("Rust", "", "", "") {
+		t.Error("include rule should drop non-matching items only")
+	}
+}
@@ -0,0 +1,183 @@
+package processor
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// urlRule 某个数据源在通用规则之外的链接规范化规则
+type urlRule struct {
+	// raw 原样保留链接，不做任何规范化
+	raw bool
+	// keepParams 非空时只保留这些查询参数
+	keepParams []string
+	// dropQuery 去掉全部查询参数
+	dropQuery bool
+}
+
+// sourceURLRules 按数据源的规范化规则
+var sourceURLRules = map[string]urlRule{
+	// 金融快照链接带 ?t=<毫秒时间戳>，每个时间点是独立的一条数据，必须原样保留
+	"gold":   {raw: true},
+	"ashare": {raw: true},
+	// 仓库与开发者主页的链接不需要查询参数
+	"github":            {dropQuery: true},
+	"github_developers": {dropQuery: true},
+	// 搜索类热榜只有关键词参数有意义
+	"baidu": {keepParams: []string{"wd", "tab"}},
+	"weibo": {keepParams: []string{"q"}},
+	"x":     {keepParams: []string{"q"}},
+}
+
+// trackingParams 通用的跟踪参数，另外所有 utm_ 开头的参数也会被去掉
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "gclsrc": true, "dclid": true, "msclkid": true, "yclid": true,
+	"twclid": true, "igshid": true, "mc_cid": true, "mc_eid": true, "_ga": true, "_gl": true,
+	"_hsenc": true, "_hsmi": true, "mkt_tok": true, "ref_src": true,
+	"spm": true, "share_source": true, "share_medium": true, "share_from": true, "isappinstalled": true,
+	"vd_source": true,
+}
+
+// hostTrackingParams 只在个别站点上是跟踪参数的名字（按合并别名后的域名）；
+// ref、si 等在其它站点上常有实际含义（git ref、文档版本等），不能全局去掉
+var hostTrackingParams = map[string][]string{
+	"x.com":               {"ref_url"},
+	"www.youtube.com":     {"si"},
+	"open.spotify.com":    {"si"},
+	"www.producthunt.com": {"ref"},
+}
+
+// hostAliases 同一站点的别名与移动版域名 → 规范域名
+var hostAliases = map[string]string{
+	"twitter.com":        "x.com",
+	"www.twitter.com":    "x.com",
+	"mobile.twitter.com": "x.com",
+	"www.x.com":          "x.com",
+	"mobile.x.com":       "x.com",
+	"youtube.com":        "www.youtube.com",
+	"m.youtube.com":      "www.youtube.com",
+	"reddit.com":         "www.reddit.com",
+	"old.reddit.com":     "www.reddit.com",
+	"np.reddit.com":      "www.reddit.com",
+	"m.reddit.com":       "www.reddit.com",
+	"www.github.com":     "github.com",
+	"bilibili.com":       "www.bilibili.com",
+	"m.bilibili.com":     "www.bilibili.com",
+	"m.facebook.com":     "www.facebook.com",
+	"facebook.com":       "www.facebook.com",
+	"www.arxiv.org":      "arxiv.org",
+	"export.arxiv.org":   "arxiv.org",
+}
+
+// CanonicalURL 返回链接的规范形式，用于去重与生成 ID：
+// 去掉跟踪参数（utm_* 等）与片段（#/ 与 #! 开头的前端路由除外），域名转小写并合并别名与移动版（twitter.com → x.com、m.youtube.com → www.youtube.com），
+// 去掉默认端口与路径末尾的 "/"，查询参数按原文排序；再叠加数据源专属规则。协议保持不变，http / https 的差异在生成 ID 时消除。
+// 无法解析或非 http(s) 的链接原样返回
+func CanonicalURL(source, rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	rule := sourceURLRules[source]
+	if rule.raw {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return rawURL
+	}
+
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if alias, ok := hostAliases[host]; ok {
+		host = alias
+	} else if strings.HasSuffix(host, ".m.wikipedia.org") {
+		host = strings.TrimSuffix(host, ".m.wikipedia.org") + ".wikipedia.org"
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	}
+	if port != "" {
+		u.Host += ":" + port
+	}
+
+	// youtu.be/<id> → www.youtube.com/watch?v=<id>
+	if host == "youtu.be" && len(u.Path) > 1 {
+		u.Host = "www.youtube.com"
+		q := "v=" + url.QueryEscape(strings.TrimPrefix(u.Path, "/"))
+		if u.RawQuery != "" {
+			q += "&" + u.RawQuery
+		}
+		u.Path, u.RawPath, u.RawQuery = "/watch", "", q
+	}
+
+	if len(u.Path) > 1 && strings.HasSuffix(u.Path, "/") {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+		if u.Path == "" {
+			u.Path, u.RawPath = "/", ""
+		}
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	if !strings.HasPrefix(u.Fragment, "/") && !strings.HasPrefix(u.Fragment, "!") {
+		u.Fragment, u.RawFragment = "", ""
+	}
+	u.RawQuery = canonicalQuery(u.RawQuery, hostTrackingParams[u.Hostname()], rule)
+	u.ForceQuery = false
+	return u.String()
+}
+
+// canonicalQuery 按参数名过滤查询串并排序，hostParams 为该站点专属的跟踪参数；保留参数原有的编码，避免改变语义
+func canonicalQuery(rawQuery string, hostParams []string, rule urlRule) string {
+	if rawQuery == "" || rule.dropQuery {
+		return ""
+	}
+	var keep []string
+	for _, part := range strings.Split(rawQuery, "&") {
+		if part == "" {
+			continue
+		}
+		name := part
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			name = part[:i]
+		}
+		if n, err := url.QueryUnescape(name); err == nil {
+			name = n
+		}
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] || containsString(hostParams, lower) {
+			continue
+		}
+		if len(rule.keepParams) > 0 && !containsString(rule.keepParams, name) {
+			continue
+		}
+		keep = append(keep, part)
+	}
+	sort.Strings(keep)
+	return strings.Join(keep, "&")
+}
+
+// dedupKey 生成 ID 使用的 key：http 与 https 视为同一链接
+func dedupKey(canonical string) string {
+	if strings.HasPrefix(canonical, "http://") {
+		return "https://" + strings.TrimPrefix(canonical, "http://")
+	}
+	return canonical
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	cases := []struct {
+		source, in, want string
+	}{
+		{"feed", "https://Example.COM/post/1/?utm_source=hn&utm_medium=rss&id=3", "https://example.com/post/1?id=3"},
+		{"feed", "https://example.com/post/1#comments", "https://example.com/post/1"},
+		{"feed", "https://example.com/app#/post/1", "https://example.com/app#/post/1"},
+		{"feed", "http://example.com:80/a?b=2&a=1&fbclid=xyz", "http://example.com/a?a=1&b=2"},
+		{"feed", "https://example.com", "https://example.com/"},
+		{"feed", "https://example.com:8443/a/", "https://example.com:8443/a"},
+		{"feed", "https://twitter.com/golang/status/1", "https://x.com/golang/status/1"},
+		{"feed", "https://mobile.twitter.com/golang/status/1?s=20", "https://x.com/golang/status/1?s=20"},
+		{"feed", "https://m.youtube.com/watch?v=abc&si=share", "https://www.youtube.com/watch?v=abc"},
+		{"feed", "https://youtu.be/abc?t=42", "https://www.youtube.com/watch?t=42&v=abc"},
+		{"feed", "https://en.m.wikipedia.org/wiki/Go_(programming_language)", "https://en.wikipedia.org/wiki/Go_(programming_language)"},
+		{"reddit", "https://old.reddit.com/r/golang/comments/abc/title/", "https://www.reddit.com/r/golang/comments/abc/title"},
+		{"feed", "https://example.com/search?q=a%20b&fbclid=abc", "https://example.com/search?q=a%20b"},
+		// ref / si 只在已知把它们用作跟踪参数的站点上去掉
+		{"feed", "https://example.com/docs?ref=v1.2&si=3", "https://example.com/docs?ref=v1.2&si=3"},
+		{"producthunt", "https://www.producthunt.com/posts/acme?ref=producthunt", "https://www.producthunt.com/posts/acme"},
+		{"feed", "https://open.spotify.com/track/abc?si=xyz", "https://open.spotify.com/track/abc"},
+		{"feed", "https://x.com/golang/status/1?ref_src=twsrc&ref_url=https%3A%2F%2Fexample.com", "https://x.com/golang/status/1"},
+		{"github", "https://github.com/acme/json?tab=readme-ov-file", "https://github.com/acme/json"},
+		{"baidu", "https://www.baidu.com/s?wd=%E5%A4%A9%E8%88%9F&sa=fyb_news&rsv_dl=fyb_news", "https://www.baidu.com/s?wd=%E5%A4%A9%E8%88%9F"},
+		{"weibo", "https://s.weibo.com/weibo?q=%23%E7%A7%8B%23&t=31&band_rank=1&Refer=top", "https://s.weibo.com/weibo?q=%23%E7%A7%8B%23"},
+		{"x", "https://x.com/search?q=%23GoLang&src=trend_click", "https://x.com/search?q=%23GoLang"},
+		// 金融快照链接原样保留
+		{"gold", "https://data-asg.goldprice.org/dbXRates/CNY?t=1700000000000", "https://data-asg.goldprice.org/dbXRates/CNY?t=1700000000000"},
+		{"ashare", "https://quote.eastmoney.com/zs000001.html?t=1700000000000", "https://quote.eastmoney.com/zs000001.html?t=1700000000000"},
+		// 非 http(s) 与无法解析的链接原样返回
+		{"feed", "mailto:someone@example.com", "mailto:someone@example.com"},
+		{"feed", "not a url", "not a url"},
+	}
+	for _, c := range cases {
+		if got := CanonicalURL(c.source, c.in); got != c.want {
+			t.Errorf("CanonicalURL(%s, %q) = %q, want %q", c.source, c.in, got, c.want)
+		}
+	}
+}
+
+func TestSimpleProcessorMergesURLVariants(t *testing.T) {
+	now := time.Now()
+	items := []collector.NewsItem{
+		{Title: "A", URL: "https://example.com/a?utm_source=x", Source: "feed", PublishedAt: now},
+		{Title: "A again", URL: "http://example.com/a/", Source: "feed", PublishedAt: now},
+		{Title: "A tweet", URL: "https://twitter.com/acme/status/1", Source: "feed", PublishedAt: now},
+		{Title: "A tweet again", URL: "https://x.com/acme/status/1#reply", Source: "feed", PublishedAt: now},
+		{Title: "Gold 1", URL: "https://data-asg.goldprice.org/dbXRates/CNY?t=1", Source: "gold", PublishedAt: now},
+		{Title: "Gold 2", URL: "https://data-asg.goldprice.org/dbXRates/CNY?t=2", Source: "gold", PublishedAt: now},
+	}
+	out := NewSimpleProcessor().Process(items)
+	if len(out) != 4 {
+		t.Fatalf("expected 4 items after canonical dedupe, got %d: %+v", len(out), out)
+	}
+	wantURLs := []string{
+		"https://example.com/a",
+		"https://x.com/acme/status/1",
+		"https://data-asg.goldprice.org/dbXRates/CNY?t=1",
+		"https://data-asg.goldprice.org/dbXRates/CNY?t=2",
+	}
+	for i, want := range wantURLs {
+		if out[i].URL != want {
+			t.Errorf("out[%d].URL = %q, want %q", i, out[i].URL, want)
+		}
+	}
+	if out[0].ID != hashURL("https://example.com/a") {
+		t.Errorf("ID should be the hash of the canonical https url")
+	}
+}
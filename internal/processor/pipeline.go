@@ -0,0 +1,124 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+)
+
+// 内置阶段的名称，渠道配置 stages 中使用
+const (
+	StageNormalize = "normalize"
+	StageDedup     = "dedup"
+	StageScore     = "score"
+)
+
+// Processor 把一次采集的原始条目转换为入库结构，并返回各阶段的处理统计
+type Processor interface {
+	Run(ctx context.Context, items []collector.NewsItem) ([]ProcessedNews, []StageStats)
+}
+
+// Stage 流水线中的一个处理阶段：返回处理后的条目（可去掉条目）与被修改的条数。
+// 阶段可以原地修改传入的切片，调用方只使用返回值
+type Stage interface {
+	Name() string
+	Apply(ctx context.Context, items []ProcessedNews) ([]ProcessedNews, int)
+}
+
+// StageStats 单个阶段的处理统计，记录在采集记录（FetchRun.Stages）中
+type StageStats struct {
+	Stage      string `json:"stage"`
+	In         int    `json:"in"`
+	Dropped    int    `json:"dropped"`
+	Modified   int    `json:"modified"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+type funcStage struct {
+	name string
+	fn   func(ctx context.Context, items []ProcessedNews) ([]ProcessedNews, int)
+}
+
+func (s funcStage) Name() string { return s.name }
+
+func (s funcStage) Apply(ctx context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+	return s.fn(ctx, items)
+}
+
+// NewStage 用函数构造阶段
+func NewStage(name string, fn func(ctx context.Context, items []ProcessedNews) ([]ProcessedNews, int)) Stage {
+	return funcStage{name: name, fn: fn}
+}
+
+// Pipeline 按注册顺序依次执行各阶段
+type Pipeline struct {
+	stages []Stage
+}
+
+// NewPipeline 按给定顺序组装流水线
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Stages 返回各阶段的名称
+func (p *Pipeline) Stages() []string {
+	names := make([]string, len(p.stages))
+	for i, st := range p.stages {
+		names[i] = st.Name()
+	}
+	return names
+}
+
+// Run 把原始条目逐字段转换为 ProcessedNews 后依次执行各阶段；ID、链接规范化与截断均由阶段完成，
+// 因此流水线中应包含 dedup 阶段（生成 ID）
+func (p *Pipeline) Run(ctx context.Context, items []collector.NewsItem) ([]ProcessedNews, []StageStats) {
+	return p.Apply(ctx, fromNewsItems(items))
+}
+
+// Apply 对已转换的条目依次执行各阶段；某阶段之后没有剩余条目时不再执行后续阶段
+func (p *Pipeline) Apply(ctx context.Context, items []ProcessedNews) ([]ProcessedNews, []StageStats) {
+	stats := make([]StageStats, 0, len(p.stages))
+	for _, st := range p.stages {
+		if len(items) == 0 {
+			break
+		}
+		in := len(items)
+		start := time.Now()
+		out, modified := st.Apply(ctx, items)
+		stats = append(stats, StageStats{
+			Stage:      st.Name(),
+			In:         in,
+			Dropped:    in - len(out),
+			Modified:   modified,
+			DurationMs: time.Since(start).Milliseconds(),
+		})
+		items = out
+	}
+	return items, stats
+}
+
+// Process 使用 context.Background() 执行流水线，只返回结果
+func (p *Pipeline) Process(items []collector.NewsItem) []ProcessedNews {
+	out, _ := p.Run(context.Background(), items)
+	return out
+}
+
+// fromNewsItems 逐字段转换，不做任何清洗
+func fromNewsItems(items []collector.NewsItem) []ProcessedNews {
+	out := make([]ProcessedNews, len(items))
+	for i, it := range items {
+		out[i] = ProcessedNews{
+			Title:               it.Title,
+			URL:                 it.URL,
+			Source:              it.Source,
+			Description:         it.Description,
+			OriginalTitle:       it.OriginalTitle,
+			OriginalDescription: it.OriginalDescription,
+			PublishedAt:         it.PublishedAt,
+			HotScore:            it.HotScore,
+			RawData:             it.RawData,
+		}
+	}
+	return out
+}
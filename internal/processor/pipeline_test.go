@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+)
+
+func TestPipelineReportsStageStats(t *testing.T) {
+	dropShort := NewStage("drop_short", func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		out := items[:0]
+		for _, it := range items {
+			if len([]rune(it.Title)) >= 3 {
+				out = append(out, it)
+			}
+		}
+		return out, 0
+	})
+	items := []collector.NewsItem{
+		{Title: "  Hello  ", URL: "https://example.com/a?utm_source=x", Source: "feed"},
+		{Title: "Hello again", URL: "https://example.com/a", Source: "feed"},
+		{Title: "Hi", URL: "https://example.com/b", Source: "feed", Description: "short"},
+		{Title: "World", URL: "https://example.com/c", Source: "feed", Description: "desc"},
+	}
+	p := NewPipeline(append(DefaultStages(), dropShort)...)
+	if got := strings.Join(p.Stages(), ","); got != "normalize,dedup,drop_short" {
+		t.Fatalf("Stages() = %s", got)
+	}
+	out, stats := p.Run(context.Background(), items)
+	if len(out) != 2 || out[0].Title != "Hello" || out[1].Title != "World" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+	want := []StageStats{
+		{Stage: "normalize", In: 4, Modified: 2},
+		{Stage: "dedup", In: 4, Dropped: 1, Modified: 1},
+		{Stage: "drop_short", In: 3, Dropped: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("stats = %+v", stats)
+	}
+	for i, w := range want {
+		got := stats[i]
+		got.DurationMs = 0
+		if got != w {
+			t.Errorf("stats[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestPipelineStopsWhenEmpty(t *testing.T) {
+	called := false
+	dropAll := NewStage("drop_all", func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		return nil, 0
+	})
+	never := NewStage("never", func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		called = true
+		return items, 0
+	})
+	out, stats := NewPipeline(dropAll, never).Run(context.Background(), []collector.NewsItem{{Title: "a", URL: "https://example.com"}})
+	if len(out) != 0 || len(stats) != 1 || called {
+		t.Fatalf("out=%v stats=%+v called=%v", out, stats, called)
+	}
+}
+
+func TestScoreStage(t *testing.T) {
+	items := []ProcessedNews{{Title: "a"}, {Title: "b"}, {Title: "c"}}
+	out, modified := ScoreStage().Apply(context.Background(), items)
+	if modified != 3 || out[0].HotScore != 3 || out[2].HotScore != 1 {
+		t.Fatalf("modified=%d out=%+v", modified, out)
+	}
+	// 采集器已给出热度时保持不变
+	items = []ProcessedNews{{Title: "a", HotScore: 42}, {Title: "b"}}
+	out, modified = ScoreStage().Apply(context.Background(), items)
+	if modified != 0 || out[0].HotScore != 42 || out[1].HotScore != 0 {
+		t.Fatalf("modified=%d out=%+v", modified, out)
+	}
+}
@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"strings"
@@ -16,48 +17,106 @@ type ProcessedNews struct {
 	URL         string
 	Source      string
 	Description string
+	// OriginalTitle / OriginalDescription 翻译前的原文，为空表示 Title / Description 即原文
+	OriginalTitle       string
+	OriginalDescription string
+	// Translations 其它语言的译文：语言代码 → {"title": ..., "description": ...}，由调度器按 TRANSLATE_LANGS 填写
+	Translations map[string]map[string]string
+	// Summary 大模型生成的中文摘要，由调度器对开启 summarize 的渠道填写
+	Summary string
+	// StoryID 跨数据源聚类得到的事件 ID，由调度器的聚类阶段填写
+	StoryID string
+	// Tags 主题标签（如 ai、finance），由调度器的打标签阶段填写；为 nil 表示未打标签，入库时不改动已有标签
+	Tags        []string
 	PublishedAt time.Time
 	HotScore    float64
 	RawData     map[string]any
 }
 
-// SimpleProcessor 做最基础的数据清洗与 ID 生成
-type SimpleProcessor struct{}
+// SimpleProcessor 默认的阶段组合：normalize（清洗与截断）→ dedup（链接规范化、ID 生成与去重）
+type SimpleProcessor struct {
+	pipeline *Pipeline
+}
 
 func NewSimpleProcessor() *SimpleProcessor {
-	return &SimpleProcessor{}
+	return &SimpleProcessor{pipeline: NewPipeline(DefaultStages()...)}
+}
+
+// DefaultStages 返回默认的阶段组合，渠道未配置 stages 时使用
+func DefaultStages() []Stage {
+	return []Stage{NormalizeStage(), DedupStage()}
+}
+
+func (p *SimpleProcessor) Run(ctx context.Context, items []collector.NewsItem) ([]ProcessedNews, []StageStats) {
+	return p.pipeline.Run(ctx, items)
 }
 
 func (p *SimpleProcessor) Process(items []collector.NewsItem) []ProcessedNews {
-	out := make([]ProcessedNews, 0, len(items))
-	seen := make(map[string]struct{})
+	return p.pipeline.Process(items)
+}
 
-	for _, it := range items {
-		id := hashURL(it.URL)
-		if _, ok := seen[id]; ok {
-			continue
+// NormalizeStage 去掉首尾空白并控制介绍长度；没有介绍时用标题兜底
+func NormalizeStage() Stage {
+	return NewStage(StageNormalize, func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		modified := 0
+		for i := range items {
+			it := &items[i]
+			before := [4]string{it.Title, it.Description, it.OriginalTitle, it.OriginalDescription}
+			it.Title = strings.TrimSpace(it.Title)
+			// description 统一在后端做长度控制，最多保留约 600 个字符
+			it.Description = TruncateRunes(strings.TrimSpace(it.Description), 600)
+			if it.Description == "" {
+				// 兜底：没有提供 description 时，用标题作为简短介绍
+				it.Description = TruncateRunes(it.Title, 600)
+			}
+			it.OriginalTitle = strings.TrimSpace(it.OriginalTitle)
+			it.OriginalDescription = TruncateRunes(it.OriginalDescription, 600)
+			if before != [4]string{it.Title, it.Description, it.OriginalTitle, it.OriginalDescription} {
+				modified++
+			}
 		}
-		seen[id] = struct{}{}
+		return items, modified
+	})
+}
 
-		// description 统一在后端做长度控制，最多保留约 600 个字符
-		desc := truncateRunes(strings.TrimSpace(it.Description), 600)
-		if desc == "" {
-			// 兜底：没有提供 description 时，用标题作为简短介绍
-			desc = truncateRunes(strings.TrimSpace(it.Title), 600)
+// DedupStage 规范化链接并生成 ID，同一批次内 ID 相同的条目只保留第一条；modified 为链接被改写的条数
+func DedupStage() Stage {
+	return NewStage(StageDedup, func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		out := items[:0]
+		seen := make(map[string]struct{}, len(items))
+		modified := 0
+		for _, it := range items {
+			// 先规范化链接再生成 ID，带跟踪参数、移动版域名等变体视为同一条
+			link := CanonicalURL(it.Source, it.URL)
+			id := hashURL(dedupKey(link))
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			if link != it.URL {
+				modified++
+			}
+			it.ID, it.URL = id, link
+			out = append(out, it)
 		}
-		out = append(out, ProcessedNews{
-			ID:          id,
-			Title:       strings.TrimSpace(it.Title),
-			URL:         it.URL,
-			Source:      it.Source,
-			Description: desc,
-			PublishedAt: it.PublishedAt,
-			HotScore:    it.HotScore,
-			RawData:     it.RawData,
-		})
-	}
+		return out, modified
+	})
+}
 
-	return out
+// ScoreStage 按榜单位置补齐热度：整批条目都没有热度（HotScore 均为 0）时，第一条最高、依次递减；
+// 已由采集器给出热度（得票数、价格等）的批次不改动。不在默认阶段中，按需在渠道 stages 中引用
+func ScoreStage() Stage {
+	return NewStage(StageScore, func(_ context.Context, items []ProcessedNews) ([]ProcessedNews, int) {
+		for _, it := range items {
+			if it.HotScore != 0 {
+				return items, 0
+			}
+		}
+		for i := range items {
+			items[i].HotScore = float64(len(items) - i)
+		}
+		return items, len(items)
+	})
 }
 
 // hashURL 仅用于去重与主键生成，非密码学用途；若需安全场景请改用 SHA256。
@@ -67,8 +126,8 @@ func hashURL(url string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// truncateRunes 按 rune 数截断字符串，避免中文被截成半个字符
-func truncateRunes(s string, limit int) string {
+// TruncateRunes 去掉首尾空白后按 rune 数截断字符串，避免中文被截成半个字符，超出部分以省略号结尾
+func TruncateRunes(s string, limit int) string {
 	if limit <= 0 {
 		return ""
 	}
@@ -81,4 +140,4 @@ func truncateRunes(s string, limit int) string {
 		return s
 	}
 	return string(rs[:limit]) + "…"
-}
\ No newline at end of file
+}
@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -25,16 +26,16 @@ func TestHashURLDeterministicAndDistinct(t *testing.T) {
 
 func TestTruncateRunesHandlesChineseAndEllipsis(t *testing.T) {
 	s := "你好，世界，这是一个很长的中文句子，用来测试截断逻辑。"
-	out := truncateRunes(s, 5)
+	out := TruncateRunes(s, 5)
 	if len([]rune(out)) != 6 { // 5 个字符 + 1 个省略号
 		t.Fatalf("truncateRunes length = %d, want 6 (including ellipsis): %q", len([]rune(out)), out)
 	}
-	if out[len(out)-3:] != "…" && out[len(out)-3:] != "…" { // 简单检查末尾是否为省略号
+	if !strings.HasSuffix(out, "…") { // 简单检查末尾是否为省略号
 		t.Fatalf("truncateRunes should append ellipsis: %q", out)
 	}
 
 	// limit 大于长度时不应截断
-	full := truncateRunes("短文本", 10)
+	full := TruncateRunes("短文本", 10)
 	if full != "短文本" {
 		t.Fatalf("truncateRunes should keep original when under limit: %q", full)
 	}
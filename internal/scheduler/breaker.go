@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+)
+
+// RetryPolicy 单次执行内对瞬时错误的重试策略，所有重试共享同一个任务 deadline
+type RetryPolicy struct {
+	// MaxAttempts 最多尝试次数（含首次）；为 0 时使用默认值，1 表示不重试
+	MaxAttempts int
+	// InitialBackoff 第一次重试前的等待时间，之后每次翻倍
+	InitialBackoff time.Duration
+	// MaxBackoff 单次等待上限
+	MaxBackoff time.Duration
+}
+
+// backoff 返回第 attempt 次失败（从 1 开始）后的等待时间
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// isRetryable 判断错误是否值得在本次执行内重试：主动跳过、永久性错误、任务 ctx 已结束都不重试
+func isRetryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, collector.ErrSkipped) || collector.IsPermanent(err) {
+		return false
+	}
+	return true
+}
+
+// 熔断器状态
+const (
+	BreakerClosed   = "closed"    // 正常采集
+	BreakerOpen     = "open"      // 连续失败过多，暂停采集
+	BreakerHalfOpen = "half-open" // 冷却结束，允许一次探测
+)
+
+// BreakerConfig 每个数据源独立的熔断配置
+type BreakerConfig struct {
+	// FailureThreshold 连续失败达到该次数后熔断；为 0 时使用默认值，小于 0 表示不启用熔断
+	FailureThreshold int
+	// OpenTimeout 熔断后等待多久进入半开状态并放行一次探测
+	OpenTimeout time.Duration
+}
+
+// BreakerStatus 熔断器状态快照，供 API 展示
+type BreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	NextProbeAt         *time.Time `json:"nextProbeAt,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	cfg       BreakerConfig
+	state     string
+	failures  int
+	openedAt  time.Time
+	lastError string
+}
+
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg, state: BreakerClosed}
+}
+
+// allow 判断本次是否放行；open 状态冷却结束后转为 half-open 并放行一次探测
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != BreakerOpen {
+		return true
+	}
+	if now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.state = BreakerHalfOpen
+		return true
+	}
+	return false
+}
+
+// onSuccess 执行成功：关闭熔断并清零失败计数
+func (b *circuitBreaker) onSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = BreakerClosed
+	b.failures = 0
+	b.lastError = ""
+}
+
+// onFailure 执行失败：half-open 探测失败立即重新熔断；closed 下连续失败达到阈值时熔断
+func (b *circuitBreaker) onFailure(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.cfg.FailureThreshold <= 0 {
+		return
+	}
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = now
+	}
+}
+
+// reset 手动恢复为 closed
+func (b *circuitBreaker) reset() {
+	b.onSuccess()
+}
+
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state != BreakerClosed && !b.openedAt.IsZero() {
+		opened := b.openedAt
+		next := opened.Add(b.cfg.OpenTimeout)
+		st.OpenedAt = &opened
+		st.NextProbeAt = &next
+	}
+	return st
+}
@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w {
+			t.Fatalf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	ctx := context.Background()
+	if !isRetryable(ctx, errors.New("connection reset")) {
+		t.Fatalf("plain error should be retryable")
+	}
+	if isRetryable(ctx, collector.ErrSkipped) {
+		t.Fatalf("ErrSkipped should not be retryable")
+	}
+	if isRetryable(ctx, fmt.Errorf("wrap: %w", collector.Permanent(errors.New("bad json")))) {
+		t.Fatalf("permanent error should not be retryable")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if isRetryable(canceled, errors.New("timeout")) {
+		t.Fatalf("error after ctx canceled should not be retryable")
+	}
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	b := newCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	now := time.Now()
+
+	b.onFailure(now, errors.New("e1"))
+	if st := b.status(); st.State != BreakerClosed || st.ConsecutiveFailures != 1 {
+		t.Fatalf("after 1 failure: %+v", st)
+	}
+	b.onFailure(now, errors.New("e2"))
+	if st := b.status(); st.State != BreakerOpen || st.NextProbeAt == nil {
+		t.Fatalf("after 2 failures expected open with next probe: %+v", st)
+	}
+	if b.allow(now.Add(30 * time.Second)) {
+		t.Fatalf("open breaker should reject before OpenTimeout")
+	}
+	if !b.allow(now.Add(time.Minute)) {
+		t.Fatalf("breaker should allow a probe after OpenTimeout")
+	}
+	if st := b.status(); st.State != BreakerHalfOpen {
+		t.Fatalf("expected half-open, got %s", st.State)
+	}
+
+	// 半开探测失败立即重新熔断
+	probeAt := now.Add(time.Minute)
+	b.onFailure(probeAt, errors.New("e3"))
+	if st := b.status(); st.State != BreakerOpen || !st.OpenedAt.Equal(probeAt) {
+		t.Fatalf("failed probe should reopen breaker: %+v", st)
+	}
+
+	b.onSuccess()
+	if st := b.status(); st.State != BreakerClosed || st.ConsecutiveFailures != 0 || st.OpenedAt != nil {
+		t.Fatalf("success should close breaker: %+v", st)
+	}
+}
+
+// flakyFetcher 前 failures 次返回错误，之后返回一条数据
+type flakyFetcher struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyFetcher) Name() string { return "flaky" }
+
+func (f *flakyFetcher) FetchContext(ctx context.Context) ([]collector.NewsItem, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("temporary failure")
+	}
+	return nil, nil
+}
+
+func TestExecuteRetriesThenOpensBreaker(t *testing.T) {
+	f := &flakyFetcher{failures: 100}
+	s, err := New([]FetcherJob{{Fetcher: f, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{
+		Retry:   RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		Breaker: BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	j, _ := s.Job("flaky")
+
+	for i := 0; i < 2; i++ {
+		s.runFetcher(j, TriggerCron)
+	}
+	if f.calls != 4 {
+		t.Fatalf("expected 2 runs x 2 attempts = 4 calls, got %d", f.calls)
+	}
+	if st, _ := s.BreakerStatus("flaky"); st.State != BreakerOpen {
+		t.Fatalf("expected breaker open, got %+v", st)
+	}
+
+	// 熔断期间 cron 触发不再访问数据源
+	s.runFetcher(j, TriggerCron)
+	if f.calls != 4 {
+		t.Fatalf("breaker open should skip fetch, calls=%d", f.calls)
+	}
+
+	// 手动触发绕过熔断，成功后恢复
+	f.failures = 0
+	run, err := s.RunJob("flaky")
+	if err != nil {
+		t.Fatalf("RunJob error: %v", err)
+	}
+	if run.Status != storage.FetchRunEmpty || run.Attempts != 1 {
+		t.Fatalf("unexpected manual run result: %+v", run)
+	}
+	if st, _ := s.BreakerStatus("flaky"); st.State != BreakerClosed {
+		t.Fatalf("expected breaker closed after successful manual run, got %+v", st)
+	}
+}
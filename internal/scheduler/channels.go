@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+	"github.com/robfig/cron/v3"
+)
+
+// FetcherFactory 根据渠道配置构造采集器
+type FetcherFactory func(ch storage.Channel) (collector.Fetcher, error)
+
+// JobFromChannel 将单个渠道转换为采集任务，校验采集器类型与 cron 表达式
+func JobFromChannel(ch storage.Channel, factories map[string]FetcherFactory) (FetcherJob, error) {
+	factory, ok := factories[ch.FetcherType]
+	if !ok {
+		return FetcherJob{}, fmt.Errorf("unknown fetcher type %q", ch.FetcherType)
+	}
+	if _, err := cron.ParseStandard(ch.CronSpec); err != nil {
+		return FetcherJob{}, fmt.Errorf("invalid cron spec %q: %w", ch.CronSpec, err)
+	}
+	if ch.TimeoutSec < 0 || ch.MaxItems < 0 {
+		return FetcherJob{}, fmt.Errorf("timeoutSec and maxItems must not be negative")
+	}
+	stages := ch.ConfigStrings("stages")
+	if len(stages) > 0 && !slices.Contains(stages, processor.StageDedup) {
+		return FetcherJob{}, fmt.Errorf("stages must include %q, which generates item ids", processor.StageDedup)
+	}
+	f, err := factory(ch)
+	if err != nil {
+		return FetcherJob{}, err
+	}
+	return FetcherJob{
+		Fetcher:   f,
+		CronSpec:  ch.CronSpec,
+		Timeout:   time.Duration(ch.TimeoutSec) * time.Second,
+		MaxItems:  ch.MaxItems,
+		Channel:   ch.Code,
+		Enrich:    ch.ConfigBool("enrich"),
+		Summarize: ch.ConfigBool("summarize"),
+		// 聚类默认开启，显式配置 cluster: false 时关闭
+		Cluster: ch.Config["cluster"] == nil || ch.ConfigBool("cluster"),
+		Stages:  stages,
+	}, nil
+}
+
+// JobsFromChannels 将 active 渠道转换为采集任务；配置无效或采集器重名的渠道只记日志并跳过，不影响其它渠道
+func JobsFromChannels(channels []storage.Channel, factories map[string]FetcherFactory) []FetcherJob {
+	jobs := make([]FetcherJob, 0, len(channels))
+	owner := make(map[string]string, len(channels))
+	for _, ch := range channels {
+		if ch.Status != storage.ChannelActive || ch.FetcherType == "" {
+			continue
+		}
+		j, err := JobFromChannel(ch, factories)
+		if err != nil {
+			log.Printf("channel %s: skip: %v", ch.Code, err)
+			continue
+		}
+		name := j.Fetcher.Name()
+		if other, dup := owner[name]; dup {
+			log.Printf("channel %s: skip: fetcher %s already used by channel %s", ch.Code, name, other)
+			continue
+		}
+		owner[name] = ch.Code
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// FetcherTypes 返回已注册的采集器类型，供管理接口展示
+func (s *Scheduler) FetcherTypes() []string {
+	types := make([]string, 0, len(s.opts.Factories))
+	for t := range s.opts.Factories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ValidateChannel 检查渠道配置能否构造出采集任务，用于管理接口在保存前校验；未设置采集器类型的渠道只用于展示，视为有效。
+// 启用的渠道与其它启用渠道的采集器重名时（如第二个同类型的内置采集器）同样视为无效，否则重新加载时会被跳过
+func (s *Scheduler) ValidateChannel(ch storage.Channel) error {
+	if ch.FetcherType == "" {
+		return nil
+	}
+	j, err := JobFromChannel(ch, s.opts.Factories)
+	if err != nil {
+		return err
+	}
+	if err := s.validateStages(j.Stages); err != nil {
+		return err
+	}
+	if ch.Status != storage.ChannelActive || s.store == nil {
+		return nil
+	}
+	channels, err := s.store.ListChannels()
+	if err != nil {
+		return fmt.Errorf("load channels: %w", err)
+	}
+	return checkFetcherConflict(ch.Code, j.Fetcher.Name(), channels, s.opts.Factories)
+}
+
+// checkFetcherConflict 检查 name 是否已被 code 以外的启用渠道的采集器使用
+func checkFetcherConflict(code, name string, channels []storage.Channel, factories map[string]FetcherFactory) error {
+	for _, other := range channels {
+		if other.Code == code || other.Status != storage.ChannelActive || other.FetcherType == "" {
+			continue
+		}
+		j, err := JobFromChannel(other, factories)
+		if err != nil {
+			continue
+		}
+		if j.Fetcher.Name() == name {
+			return fmt.Errorf("fetcher %s is already used by channel %s; fetcher type %s supports only one active channel", name, other.Code, other.FetcherType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+type namedFetcher string
+
+func (n namedFetcher) Name() string { return string(n) }
+
+func (n namedFetcher) FetchContext(ctx context.Context) ([]collector.NewsItem, error) {
+	return nil, nil
+}
+
+func testFactories() map[string]FetcherFactory {
+	return map[string]FetcherFactory{
+		"stub": func(ch storage.Channel) (collector.Fetcher, error) { return namedFetcher("stub"), nil },
+		"per_channel": func(ch storage.Channel) (collector.Fetcher, error) {
+			return namedFetcher("feed_" + ch.Code), nil
+		},
+	}
+}
+
+func TestJobsFromChannels(t *testing.T) {
+	channels := []storage.Channel{
+		{Code: "a", Status: storage.ChannelActive, FetcherType: "stub", CronSpec: "*/5 * * * *", TimeoutSec: 30, MaxItems: 10},
+		{Code: "b", Status: storage.ChannelDisabled, FetcherType: "per_channel", CronSpec: "0 * * * *"},
+		{Code: "c", Status: storage.ChannelActive, FetcherType: "unknown", CronSpec: "0 * * * *"},
+		{Code: "d", Status: storage.ChannelActive, FetcherType: "per_channel", CronSpec: "not a cron"},
+		{Code: "e", Status: storage.ChannelActive, FetcherType: "stub", CronSpec: "0 * * * *"}, // 与 a 的采集器重名
+		{Code: "f", Status: storage.ChannelActive, FetcherType: "per_channel", CronSpec: "@every 1h", Config: map[string]any{"summarize": true, "enrich": "true", "cluster": false}},
+		{Code: "g", Status: storage.ChannelActive}, // 仅展示，不采集
+	}
+	jobs := JobsFromChannels(channels, testFactories())
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d: %+v", len(jobs), jobs)
+	}
+	if jobs[0].Channel != "a" || jobs[0].Fetcher.Name() != "stub" || jobs[0].MaxItems != 10 || jobs[0].EffectiveTimeout().Seconds() != 30 || jobs[0].Summarize || jobs[0].Enrich || !jobs[0].Cluster {
+		t.Fatalf("unexpected job for channel a: %+v", jobs[0])
+	}
+	if jobs[1].Channel != "f" || jobs[1].Fetcher.Name() != "feed_f" || !jobs[1].Summarize || !jobs[1].Enrich || jobs[1].Cluster {
+		t.Fatalf("unexpected job for channel f: %+v", jobs[1])
+	}
+}
+
+func TestCheckFetcherConflict(t *testing.T) {
+	channels := []storage.Channel{
+		{Code: "a", Status: storage.ChannelActive, FetcherType: "stub", CronSpec: "0 * * * *"},
+		{Code: "b", Status: storage.ChannelActive, FetcherType: "per_channel", CronSpec: "0 * * * *"},
+	}
+	if err := checkFetcherConflict("e", "stub", channels, testFactories()); err == nil {
+		t.Fatal("expected a second channel of a built-in fetcher type to be rejected")
+	}
+	// 修改渠道自身、或每个渠道各自命名的采集器不冲突
+	if err := checkFetcherConflict("a", "stub", channels, testFactories()); err != nil {
+		t.Fatalf("updating the owning channel: %v", err)
+	}
+	if err := checkFetcherConflict("c", "feed_c", channels, testFactories()); err != nil {
+		t.Fatalf("per-channel fetcher: %v", err)
+	}
+	// 停用的渠道不占用采集器
+	channels[0].Status = storage.ChannelDisabled
+	if err := checkFetcherConflict("e", "stub", channels, testFactories()); err != nil {
+		t.Fatalf("disabled owner: %v", err)
+	}
+}
+
+func TestSetJobsReplacesEntriesAndKeepsBreakers(t *testing.T) {
+	s, err := New([]FetcherJob{
+		{Fetcher: namedFetcher("keep"), CronSpec: "0 * * * *"},
+		{Fetcher: namedFetcher("drop"), CronSpec: "0 * * * *"},
+	}, processor.NewSimpleProcessor(), nil, Options{})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	keepBreaker, _ := s.breaker("keep")
+	keepEntry := s.entries["keep"].id
+
+	if err := s.SetJobs([]FetcherJob{
+		{Fetcher: namedFetcher("keep"), CronSpec: "0 * * * *", MaxItems: 5},
+		{Fetcher: namedFetcher("new"), CronSpec: "*/10 * * * *"},
+	}); err != nil {
+		t.Fatalf("SetJobs error: %v", err)
+	}
+	if _, ok := s.Job("drop"); ok {
+		t.Fatalf("expected drop to be removed")
+	}
+	if j, ok := s.Job("keep"); !ok || j.MaxItems != 5 {
+		t.Fatalf("expected keep with updated MaxItems, got %+v ok=%v", j, ok)
+	}
+	if b, _ := s.breaker("keep"); b != keepBreaker {
+		t.Fatalf("expected breaker state to survive reload")
+	}
+	if s.entries["keep"].id != keepEntry {
+		t.Fatalf("expected unchanged cron spec to keep its entry")
+	}
+	if len(s.Cron().Entries()) != 2 {
+		t.Fatalf("expected 2 cron entries, got %d", len(s.Cron().Entries()))
+	}
+
+	if err := s.SetJobs([]FetcherJob{{Fetcher: namedFetcher("bad"), CronSpec: "nope"}}); err == nil {
+		t.Fatalf("expected invalid cron spec to be rejected")
+	}
+	if _, ok := s.Job("keep"); !ok {
+		t.Fatalf("rejected SetJobs must not change current jobs")
+	}
+}
@@ -1,8 +1,12 @@
 package scheduler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/LJTian/TrendingHub/internal/collector"
 	"github.com/LJTian/TrendingHub/internal/processor"
@@ -10,38 +14,302 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// defaultFetchTimeout 未配置 Timeout 的任务单次执行的最长时间
+const defaultFetchTimeout = 2 * time.Minute
+
 // FetcherJob 将采集器与独立的 cron 调度绑定
 type FetcherJob struct {
 	Fetcher  collector.Fetcher
 	CronSpec string
+	// Timeout 单次采集的 deadline，应小于 cron 周期，避免慢数据源导致多次执行堆积；为 0 时使用 defaultFetchTimeout
+	Timeout time.Duration
+	// MaxItems 单次采集最多处理入库的条数（按数据源返回顺序截取），为 0 时不限制
+	MaxItems int
+	// Channel 任务来源的渠道 code，由 channels 表加载时填写
+	Channel string
+	// Enrich 入库前是否调用 Options.Enricher 抓取链接预览，对应渠道配置 enrich
+	Enrich bool
+	// Summarize 入库前是否调用 Options.Summarizer 生成摘要，对应渠道配置 summarize
+	Summarize bool
+	// Cluster 入库前是否调用 Options.Clusterer 归入跨数据源的 story，渠道配置 cluster 为 false 时关闭
+	Cluster bool
+	// Stages 按顺序执行的处理阶段名称，对应渠道配置 stages；为空时使用默认处理器，再按 Enrich / Summarize / Cluster 追加阶段
+	Stages []string
+}
+
+// EffectiveTimeout 返回单次执行实际使用的超时时间
+func (j FetcherJob) EffectiveTimeout() time.Duration {
+	if j.Timeout > 0 {
+		return j.Timeout
+	}
+	return defaultFetchTimeout
+}
+
+// 采集触发来源，记录在 FetchRun.Trigger 中
+const (
+	TriggerCron    = "cron"
+	TriggerStartup = "startup"
+	TriggerManual  = "manual"
+)
+
+var (
+	// ErrJobNotFound 指定名称的采集任务不存在
+	ErrJobNotFound = errors.New("fetcher job not found")
+	// ErrJobRunning 同一采集任务已在执行中
+	ErrJobRunning = errors.New("fetcher job is already running")
+	// ErrJobLocked 同一采集任务正由其它副本执行
+	ErrJobLocked = errors.New("fetcher job is running on another replica")
+	// ErrTickClaimed 本次调度周期已由其它副本（或本副本此前的执行）采集过
+	ErrTickClaimed = errors.New("fetcher job already ran for this tick")
+	// ErrStopped 调度器已停止，不再接受新的执行
+	ErrStopped = errors.New("scheduler is stopped")
+)
+
+// 同一任务上一次 cron 执行尚未结束时的处理方式
+const (
+	OverlapSkip  = "skip"  // 跳过本次 tick（默认）
+	OverlapQueue = "queue" // 排队，等上一次结束后立即执行
+)
+
+// lockKeyPrefix 分布式租约在 Redis 中的 key 前缀，后接 Fetcher.Name()；
+// 调度周期的租约再后接 ":" 与周期起点的 Unix 秒，见 claimTick
+const lockKeyPrefix = "trendinghub:lock:fetch:"
+
+// processTimeout 采集完成后处理阶段（预览、翻译、摘要等）的时间预算，与采集超时分开计算，
+// 慢数据源用完采集超时也不会让后续阶段拿到已到期的 context
+const processTimeout = 5 * time.Minute
+
+// lockTTLMargin 租约 TTL 在采集超时与处理预算之外额外预留的时间，覆盖入库耗时
+const lockTTLMargin = time.Minute
+
+// Locker 跨副本的任务互斥，由 storage.Store 基于 Redis 实现
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// Options 调度器的可选配置，零值字段使用默认值
+type Options struct {
+	Retry   RetryPolicy
+	Breaker BreakerConfig
+	// Overlap 同一任务 cron 执行重叠时的策略：skip / queue，默认 skip
+	Overlap string
+	// Locker 可选，多副本部署时保证同一数据源同一时刻只有一个副本在采集；为空时只做进程内互斥
+	Locker Locker
+	// Factories 采集器类型到构造函数的映射，Reload 据此把 channels 表中的渠道转换为采集任务；
+	// ValidateChannel 在保存前也会调用，构造函数只应校验配置，不应产生副作用
+	Factories map[string]FetcherFactory
+	// Prepare 可选，Reload 在构造任务前对每个已保存的渠道（含停用渠道）调用，用于注册运行时数据源等；
+	// 返回错误时该渠道本次不调度。ValidateChannel 不调用
+	Prepare func(ch storage.Channel) error
+	// Languages 除默认的中文外，采集后额外翻译的目标语言（如 en、ja），译文写入 News.Translations
+	Languages []string
+	// Filter 可选，入库前按过滤规则去掉条目；为空时不过滤
+	Filter Filter
+	// Enricher 可选，为开启 enrich 的任务抓取链接预览；为空时不抓取
+	Enricher Enricher
+	// Tagger 可选，为条目打主题标签；为空时不打标签
+	Tagger Tagger
+	// Summarizer 可选，为开启 summarize 的任务生成摘要；为空时不生成
+	Summarizer Summarizer
+	// Clusterer 可选，把标题相近的条目归入同一 story；为空时不聚类
+	Clusterer Clusterer
+	// Stages 可选，额外注册的处理阶段（名称 → 阶段），渠道配置 stages 中可按名称引用；与内置阶段重名时覆盖内置阶段
+	Stages map[string]processor.Stage
+}
+
+// Filter 按过滤规则返回保留的条目，由 filter.Filter 实现
+type Filter interface {
+	FilterItems(ctx context.Context, items []processor.ProcessedNews) []processor.ProcessedNews
+}
+
+// Enricher 为处理后的条目补充链接预览（写入 RawData），返回带有预览的条目数，由 enricher.Enricher 实现
+type Enricher interface {
+	EnrichItems(ctx context.Context, items []processor.ProcessedNews) int
+}
+
+// Tagger 为处理后的条目填写 Tags，返回至少有一个标签的条目数，由 tagger.Tagger 实现
+type Tagger interface {
+	TagItems(ctx context.Context, items []processor.ProcessedNews) int
+}
+
+// Summarizer 为处理后的条目填写 Summary，返回填写了摘要的条目数，由 summarizer.Summarizer 实现
+type Summarizer interface {
+	SummarizeItems(ctx context.Context, items []processor.ProcessedNews) int
+}
+
+// Clusterer 为处理后的条目填写 StoryID，返回填写了 StoryID 的条目数，由 story.Clusterer 实现
+type Clusterer interface {
+	ClusterItems(ctx context.Context, items []processor.ProcessedNews) int
+}
+
+var defaultOptions = Options{
+	Retry:   RetryPolicy{MaxAttempts: 3, InitialBackoff: 2 * time.Second, MaxBackoff: 30 * time.Second},
+	Breaker: BreakerConfig{FailureThreshold: 5, OpenTimeout: 30 * time.Minute},
+}
+
+func (o Options) withDefaults() Options {
+	if o.Retry.MaxAttempts == 0 {
+		o.Retry.MaxAttempts = defaultOptions.Retry.MaxAttempts
+	}
+	if o.Retry.InitialBackoff <= 0 {
+		o.Retry.InitialBackoff = defaultOptions.Retry.InitialBackoff
+	}
+	if o.Retry.MaxBackoff <= 0 {
+		o.Retry.MaxBackoff = defaultOptions.Retry.MaxBackoff
+	}
+	if o.Breaker.FailureThreshold == 0 {
+		o.Breaker.FailureThreshold = defaultOptions.Breaker.FailureThreshold
+	}
+	if o.Breaker.OpenTimeout <= 0 {
+		o.Breaker.OpenTimeout = defaultOptions.Breaker.OpenTimeout
+	}
+	if o.Overlap != OverlapQueue {
+		o.Overlap = OverlapSkip
+	}
+	return o
 }
 
 type Scheduler struct {
 	cron      *cron.Cron
-	jobs      []FetcherJob
-	processor *processor.SimpleProcessor
+	processor processor.Processor
 	store     *storage.Store
+	opts      Options
+	// stages 内置与 Options.Stages 注册的处理阶段，按名称查找
+	stages map[string]processor.Stage
+
+	// jobs / entries / breakers 会被 Reload 整体替换，读写均需持有 jobsMu
+	jobsMu  sync.RWMutex
+	jobs    []FetcherJob
+	entries map[string]scheduledEntry
+	// breakers 每个数据源独立的熔断器，key 为 Fetcher.Name()；任务重新加载时保留已有状态
+	breakers map[string]*circuitBreaker
+	reloadMu sync.Mutex
+
+	// running 记录正在执行的任务名及其分布式租约的释放函数，
+	// 保证同一任务同一时刻只有一次执行（cron / 启动 / 手动触发共用）
+	runningMu sync.Mutex
+	running   map[string]func()
+	stopped   bool
+	inflight  sync.WaitGroup
+
+	// ctx 是所有采集执行的父 context，Stop 超时后取消以中断仍在进行的采集
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func New(jobs []FetcherJob, p *processor.SimpleProcessor, store *storage.Store) (*Scheduler, error) {
-	c := cron.New()
+// scheduledEntry 已注册到 cron 的任务条目，cron 表达式变化时才需要重新注册
+type scheduledEntry struct {
+	id   cron.EntryID
+	spec string
+}
+
+func New(jobs []FetcherJob, p processor.Processor, store *storage.Store, opts Options) (*Scheduler, error) {
+	opts = opts.withDefaults()
+	logger := cron.PrintfLogger(log.Default())
+	wrapper := cron.SkipIfStillRunning(logger)
+	if opts.Overlap == OverlapQueue {
+		wrapper = cron.DelayIfStillRunning(logger)
+	}
+	c := cron.New(cron.WithChain(cron.Recover(logger), wrapper))
+	ctx, cancel := context.WithCancel(context.Background())
 
 	s := &Scheduler{
+		ctx:       ctx,
+		cancel:    cancel,
 		cron:      c,
-		jobs:      jobs,
 		processor: p,
 		store:     store,
+		opts:      opts,
+		entries:   make(map[string]scheduledEntry),
+		breakers:  make(map[string]*circuitBreaker),
+		running:   make(map[string]func()),
+	}
+	s.stages = s.builtinStages()
+	for name, st := range opts.Stages {
+		s.stages[name] = st
+	}
+	if err := s.SetJobs(jobs); err != nil {
+		return nil, err
 	}
+	return s, nil
+}
 
-	for _, job := range jobs {
-		j := job
-		if _, err := c.AddFunc(j.CronSpec, func() { s.runFetcher(j.Fetcher) }); err != nil {
-			return nil, err
+// SetJobs 用给定任务整体替换当前任务列表：新增的任务注册到 cron，删除的任务移出 cron，
+// cron 表达式未变的任务保持原条目。正在执行的任务不受影响，会按旧配置执行完。
+func (s *Scheduler) SetJobs(jobs []FetcherJob) error {
+	seen := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		name := j.Fetcher.Name()
+		if seen[name] {
+			return fmt.Errorf("duplicate fetcher job %q", name)
+		}
+		seen[name] = true
+		if _, err := cron.ParseStandard(j.CronSpec); err != nil {
+			return fmt.Errorf("fetcher job %q: invalid cron spec %q: %w", name, j.CronSpec, err)
 		}
-		log.Printf("scheduled %s with cron: %s", j.Fetcher.Name(), j.CronSpec)
 	}
 
-	return s, nil
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for name, e := range s.entries {
+		if !seen[name] {
+			s.cron.Remove(e.id)
+			delete(s.entries, name)
+			delete(s.breakers, name)
+			log.Printf("unscheduled %s", name)
+		}
+	}
+	for _, j := range jobs {
+		name := j.Fetcher.Name()
+		if _, ok := s.breakers[name]; !ok {
+			s.breakers[name] = newCircuitBreaker(s.opts.Breaker)
+		}
+		if e, ok := s.entries[name]; ok {
+			if e.spec == j.CronSpec {
+				continue
+			}
+			s.cron.Remove(e.id)
+		}
+		// cron 回调按名称取最新配置，任务参数变化时无需重新注册
+		id, err := s.cron.AddFunc(j.CronSpec, func() { s.runScheduled(name) })
+		if err != nil {
+			return err
+		}
+		s.entries[name] = scheduledEntry{id: id, spec: j.CronSpec}
+		log.Printf("scheduled %s with cron: %s, timeout: %v", name, j.CronSpec, j.EffectiveTimeout())
+	}
+	s.jobs = jobs
+	return nil
+}
+
+// Reload 从 channels 表重新加载采集任务：只有 active 且采集器类型已注册的渠道会被调度
+func (s *Scheduler) Reload() error {
+	if s.store == nil {
+		return errors.New("scheduler has no store to load channels from")
+	}
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	channels, err := s.store.ListChannels()
+	if err != nil {
+		return err
+	}
+	return s.SetJobs(JobsFromChannels(s.prepareChannels(channels), s.opts.Factories))
+}
+
+// prepareChannels 对渠道调用 Options.Prepare，返回准备成功的渠道；失败的渠道只记日志
+func (s *Scheduler) prepareChannels(channels []storage.Channel) []storage.Channel {
+	if s.opts.Prepare == nil {
+		return channels
+	}
+	ready := channels[:0:0]
+	for _, ch := range channels {
+		if err := s.opts.Prepare(ch); err != nil {
+			log.Printf("channel %s: skip: prepare: %v", ch.Code, err)
+			continue
+		}
+		ready = append(ready, ch)
+	}
+	return ready
 }
 
 func (s *Scheduler) Start() {
@@ -49,6 +317,32 @@ func (s *Scheduler) Start() {
 	go s.RunOnce()
 }
 
+// Stop 停止调度：不再触发新的执行，等待 cron 任务与进行中的采集（含手动触发）结束；
+// ctx 到期时取消所有采集的 context 并返回 ctx.Err()。
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.runningMu.Lock()
+	s.stopped = true
+	s.runningMu.Unlock()
+
+	cronCtx := s.cron.Stop()
+	done := make(chan struct{})
+	go func() {
+		<-cronCtx.Done()
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		log.Printf("scheduler: stop deadline reached, cancel in-flight fetches")
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
 // Cron 暴露底层 cron 实例，方便外部注册额外任务
 func (s *Scheduler) Cron() *cron.Cron {
 	return s.cron
@@ -58,44 +352,350 @@ func (s *Scheduler) Cron() *cron.Cron {
 func (s *Scheduler) RunOnce() {
 	log.Println("start collect job (all sources)...")
 	var wg sync.WaitGroup
-	for _, job := range s.jobs {
+	for _, job := range s.Jobs() {
 		j := job
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			s.runFetcher(j.Fetcher)
+			s.runFetcher(j, TriggerStartup)
 		}()
 	}
 	wg.Wait()
 	log.Println("collect job done (all sources)")
 }
 
-func (s *Scheduler) runFetcher(f collector.Fetcher) {
+// Jobs 返回所有已注册的采集任务
+func (s *Scheduler) Jobs() []FetcherJob {
+	s.jobsMu.RLock()
+	defer s.jobsMu.RUnlock()
+	return append([]FetcherJob(nil), s.jobs...)
+}
+
+// Job 按 Fetcher.Name() 查找采集任务
+func (s *Scheduler) Job(name string) (FetcherJob, bool) {
+	s.jobsMu.RLock()
+	defer s.jobsMu.RUnlock()
+	for _, j := range s.jobs {
+		if j.Fetcher.Name() == name {
+			return j, true
+		}
+	}
+	return FetcherJob{}, false
+}
+
+// IsRunning 判断指定任务当前是否在执行
+func (s *Scheduler) IsRunning(name string) bool {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	_, ok := s.running[name]
+	return ok
+}
+
+func (s *Scheduler) breaker(name string) (*circuitBreaker, bool) {
+	s.jobsMu.RLock()
+	defer s.jobsMu.RUnlock()
+	b, ok := s.breakers[name]
+	return b, ok
+}
+
+// BreakerStatus 返回指定任务的熔断器状态
+func (s *Scheduler) BreakerStatus(name string) (BreakerStatus, bool) {
+	b, ok := s.breaker(name)
+	if !ok {
+		return BreakerStatus{}, false
+	}
+	return b.status(), true
+}
+
+// ResetBreaker 手动将指定任务的熔断器恢复为 closed
+func (s *Scheduler) ResetBreaker(name string) error {
+	b, ok := s.breaker(name)
+	if !ok {
+		return ErrJobNotFound
+	}
+	b.reset()
+	return nil
+}
+
+// RunJob 手动同步执行指定采集任务一次，与 cron 走相同的采集 → 处理 → 入库路径；
+// 任务正在执行时返回 ErrJobRunning。手动触发不受熔断限制，其结果同样会更新熔断器（可用作探测）。
+func (s *Scheduler) RunJob(name string) (*storage.FetchRun, error) {
+	j, ok := s.Job(name)
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	run, err := s.beginRun(j, TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	s.execute(j, run, true)
+	return run, nil
+}
+
+// StartJob 手动异步执行指定采集任务，立即返回已入库的运行记录 ID，可通过运行记录接口轮询结果
+func (s *Scheduler) StartJob(name string) (uint, error) {
+	j, ok := s.Job(name)
+	if !ok {
+		return 0, ErrJobNotFound
+	}
+	run, err := s.beginRun(j, TriggerManual)
+	if err != nil {
+		return 0, err
+	}
+	id := run.ID
+	go s.execute(j, run, true)
+	return id, nil
+}
+
+// runScheduled 由 cron 调用，按名称取当前配置执行；任务已被移除时忽略
+func (s *Scheduler) runScheduled(name string) {
+	j, ok := s.Job(name)
+	if !ok {
+		return
+	}
+	s.runFetcher(j, TriggerCron)
+}
+
+// runFetcher 由 cron / 启动时调用；任务仍在执行时跳过本次
+func (s *Scheduler) runFetcher(j FetcherJob, trigger string) {
+	run, err := s.beginRun(j, trigger)
+	if err != nil {
+		log.Printf("skip %s (%s): %v", j.Fetcher.Name(), trigger, err)
+		return
+	}
+	s.execute(j, run, false)
+}
+
+// beginRun 占用任务的执行权（进程内 + 可选的分布式租约）并写入一条 running 状态的运行记录
+func (s *Scheduler) beginRun(j FetcherJob, trigger string) (*storage.FetchRun, error) {
+	name := j.Fetcher.Name()
+	s.runningMu.Lock()
+	if s.stopped {
+		s.runningMu.Unlock()
+		return nil, ErrStopped
+	}
+	if _, ok := s.running[name]; ok {
+		s.runningMu.Unlock()
+		return nil, ErrJobRunning
+	}
+	s.running[name] = nil
+	s.inflight.Add(1)
+	s.runningMu.Unlock()
+
+	unlock, err := s.acquireLease(j)
+	if err != nil {
+		s.endRun(name)
+		return nil, err
+	}
+	s.runningMu.Lock()
+	s.running[name] = unlock
+	s.runningMu.Unlock()
+	// 手动触发总是执行；cron 与启动时的执行每个调度周期只在一个副本上进行一次
+	if trigger != TriggerManual {
+		if err := s.claimTick(j, time.Now()); err != nil {
+			s.endRun(name)
+			return nil, err
+		}
+	}
+
+	run := &storage.FetchRun{
+		Fetcher:   name,
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+		Status:    storage.FetchRunRunning,
+	}
+	s.recordRun(run)
+	return run, nil
+}
+
+// acquireLease 获取跨副本租约；Redis 不可用时放行（退化为单副本行为），避免缓存故障导致全部数据源停止采集
+func (s *Scheduler) acquireLease(j FetcherJob) (func(), error) {
+	if s.opts.Locker == nil {
+		return nil, nil
+	}
+	name := j.Fetcher.Name()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	unlock, ok, err := s.opts.Locker.TryLock(ctx, lockKeyPrefix+name, j.EffectiveTimeout()+processTimeout+lockTTLMargin)
+	if err != nil {
+		log.Printf("acquire lease for %s failed, run without it: %v", name, err)
+		return nil, nil
+	}
+	if !ok {
+		return nil, ErrJobLocked
+	}
+	return unlock, nil
+}
+
+// claimTick 占用任务当前调度周期的租约，租约不主动释放、到下一周期后过期。
+// acquireLease 只保证同一时刻只有一个副本在采集，执行结束即释放；副本 B 的 cron 或启动执行
+// 紧跟在副本 A 之后触发时仍会重复采集同一周期（A 股快照链接原样保留，会重复入库），由此避免
+func (s *Scheduler) claimTick(j FetcherJob, now time.Time) error {
+	if s.opts.Locker == nil {
+		return nil
+	}
+	name := j.Fetcher.Name()
+	tick, next, err := scheduleTick(j.CronSpec, now)
+	if err != nil {
+		return err
+	}
+	ttl := next.Sub(now) + lockTTLMargin
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, ok, err := s.opts.Locker.TryLock(ctx, fmt.Sprintf("%s%s:%d", lockKeyPrefix, name, tick.Unix()), ttl)
+	if err != nil {
+		log.Printf("claim tick for %s failed, run without it: %v", name, err)
+		return nil
+	}
+	if !ok {
+		return ErrTickClaimed
+	}
+	return nil
+}
+
+// scheduleTick 返回 now 所在调度周期的起点（不晚于 now 的最近一次触发时间）与下一次触发时间。
+// @every 按本地启动时间计时，各副本不一致，改为按间隔对齐到 Unix 纪元
+func scheduleTick(spec string, now time.Time) (tick, next time.Time, err error) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if every, ok := sched.(cron.ConstantDelaySchedule); ok {
+		tick = now.Truncate(every.Delay)
+		return tick, tick.Add(every.Delay), nil
+	}
+	next = sched.Next(now)
+	// 从 now 往前逐步扩大窗口，找到不晚于 now 的最近一次触发时间
+	for window := time.Minute; window <= 400*24*time.Hour; window *= 2 {
+		t := sched.Next(now.Add(-window))
+		if t.After(now) {
+			continue
+		}
+		for n := sched.Next(t); !n.After(now); n = sched.Next(n) {
+			t = n
+		}
+		return t, next, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("cron spec %q: no tick within a year", spec)
+}
+
+// endRun 释放进程内执行权与分布式租约
+func (s *Scheduler) endRun(name string) {
+	s.runningMu.Lock()
+	unlock := s.running[name]
+	delete(s.running, name)
+	s.runningMu.Unlock()
+	if unlock != nil {
+		unlock()
+	}
+	s.inflight.Done()
+}
+
+// execute 执行一次采集 → 处理 → 入库，结束后释放执行权、更新熔断器与运行记录。
+// force 为 true 时忽略熔断状态（手动触发）。
+func (s *Scheduler) execute(j FetcherJob, run *storage.FetchRun, force bool) {
+	f := j.Fetcher
+	name := f.Name()
+	br, _ := s.breaker(name)
+	// fetchErr / fetched 只反映数据源本身的结果，入库失败不计入熔断
+	var (
+		fetchErr error
+		fetched  bool
+	)
+	defer s.endRun(name)
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("fetch %s panic recovered: %v", f.Name(), r)
+			log.Printf("fetch %s panic recovered: %v", name, r)
+			run.Panic = true
+			run.Error = fmt.Sprint(r)
+			fetchErr = fmt.Errorf("panic: %v", r)
+		}
+		now := time.Now()
+		if br != nil {
+			if fetchErr != nil {
+				br.onFailure(now, fetchErr)
+			} else if fetched {
+				br.onSuccess()
+			}
 		}
+		run.Finish(now)
+		s.recordRun(run)
 	}()
-	name := f.Name()
+
+	if !force && br != nil && !br.allow(time.Now()) {
+		run.Status = storage.FetchRunCircuitOpen
+		log.Printf("skip %s: circuit breaker open", name)
+		return
+	}
 	log.Printf("fetch from %s...", name)
 
-	items, err := f.Fetch()
+	ctx, cancel := context.WithTimeout(s.ctx, j.EffectiveTimeout())
+	defer cancel()
+
+	items, err := s.fetchWithRetry(ctx, f, run)
+	run.Fetched = len(items)
+	if errors.Is(err, collector.ErrSkipped) {
+		run.Status = storage.FetchRunSkipped
+		return
+	}
 	if err != nil {
-		log.Printf("fetch %s error: %v", name, err)
+		fetchErr = err
+		run.Error = err.Error()
+		log.Printf("fetch %s error after %v (%d attempts): %v", name, time.Since(run.StartedAt).Round(time.Millisecond), run.Attempts, err)
 		return
 	}
+	fetched = true
 	if len(items) == 0 {
 		log.Printf("fetch %s got 0 items", name)
 		return
 	}
+	if j.MaxItems > 0 && len(items) > j.MaxItems {
+		items = items[:j.MaxItems]
+	}
 
-	processed := s.processor.Process(items)
+	pctx, pcancel := context.WithTimeout(s.ctx, processTimeout)
+	defer pcancel()
+	processed, stats := s.process(pctx, j, items)
+	run.Stages = stats
 	if len(processed) == 0 {
 		return
 	}
 	if err := s.store.SaveBatch(processed); err != nil {
+		run.Error = "save batch: " + err.Error()
 		log.Printf("save %s batch error: %v", name, err)
 		return
 	}
+	run.Saved = len(processed)
 	log.Printf("%s done, fetched=%d saved=%d items", name, len(items), len(processed))
 }
+
+// fetchWithRetry 按 RetryPolicy 对瞬时错误做指数退避重试，所有尝试共享 ctx 的 deadline
+func (s *Scheduler) fetchWithRetry(ctx context.Context, f collector.Fetcher, run *storage.FetchRun) ([]collector.NewsItem, error) {
+	policy := s.opts.Retry
+	for attempt := 1; ; attempt++ {
+		run.Attempts = attempt
+		items, err := f.FetchContext(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !isRetryable(ctx, err) {
+			return items, err
+		}
+		wait := policy.backoff(attempt)
+		log.Printf("fetch %s attempt %d/%d failed: %v; retry in %v", f.Name(), attempt, policy.MaxAttempts, err, wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return items, err
+		}
+	}
+}
+
+// recordRun 持久化采集记录；写入失败只记日志，不影响采集本身
+func (s *Scheduler) recordRun(run *storage.FetchRun) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.SaveFetchRun(run); err != nil {
+		log.Printf("record fetch run %s error: %v", run.Fetcher, err)
+	}
+}
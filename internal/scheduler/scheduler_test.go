@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+// blockingFetcher 在 release 关闭前阻塞，用于模拟慢数据源
+type blockingFetcher struct {
+	name    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingFetcher) Name() string { return b.name }
+
+func (b *blockingFetcher) FetchContext(ctx context.Context) ([]collector.NewsItem, error) {
+	close(b.started)
+	select {
+	case <-b.release:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type skippingFetcher struct{}
+
+func (skippingFetcher) Name() string { return "skipper" }
+
+func (skippingFetcher) FetchContext(ctx context.Context) ([]collector.NewsItem, error) {
+	return nil, collector.ErrSkipped
+}
+
+func TestRunJobNotFound(t *testing.T) {
+	s, err := New(nil, processor.NewSimpleProcessor(), nil, Options{})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	if _, err := s.RunJob("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestRunJobRejectsConcurrentRun(t *testing.T) {
+	f := &blockingFetcher{name: "slow", started: make(chan struct{}), release: make(chan struct{})}
+	s, err := New([]FetcherJob{{Fetcher: f, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+
+	if _, err := s.StartJob("slow"); err != nil {
+		t.Fatalf("StartJob error: %v", err)
+	}
+	<-f.started
+	if !s.IsRunning("slow") {
+		t.Fatalf("expected job to be running")
+	}
+	if _, err := s.RunJob("slow"); !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("expected ErrJobRunning, got %v", err)
+	}
+
+	close(f.release)
+	deadline := time.Now().Add(time.Second)
+	for s.IsRunning("slow") {
+		if time.Now().After(deadline) {
+			t.Fatalf("job still running after release")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunJobRecordsSkipped(t *testing.T) {
+	s, err := New([]FetcherJob{{Fetcher: skippingFetcher{}, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	run, err := s.RunJob("skipper")
+	if err != nil {
+		t.Fatalf("RunJob error: %v", err)
+	}
+	if run.Status != storage.FetchRunSkipped {
+		t.Fatalf("Status = %q, want %q", run.Status, storage.FetchRunSkipped)
+	}
+	if run.FinishedAt.IsZero() {
+		t.Fatalf("FinishedAt should be set")
+	}
+}
+
+// fakeLocker 模拟 Redis 租约：held 中的 key 视为被其它副本持有
+type fakeLocker struct {
+	held     map[string]bool
+	unlocked []string
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	if l.held[key] {
+		return nil, false, nil
+	}
+	l.held[key] = true
+	return func() {
+		delete(l.held, key)
+		l.unlocked = append(l.unlocked, key)
+	}, true, nil
+}
+
+func TestRunJobUsesDistributedLease(t *testing.T) {
+	locker := &fakeLocker{held: map[string]bool{}}
+	s, err := New([]FetcherJob{{Fetcher: skippingFetcher{}, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{Locker: locker})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+
+	if _, err := s.RunJob("skipper"); err != nil {
+		t.Fatalf("RunJob error: %v", err)
+	}
+	if len(locker.unlocked) != 1 || locker.unlocked[0] != lockKeyPrefix+"skipper" {
+		t.Fatalf("expected lease to be released once, got %v", locker.unlocked)
+	}
+
+	// 其它副本持有租约时拒绝执行，且不占用本地执行权
+	locker.held[lockKeyPrefix+"skipper"] = true
+	if _, err := s.RunJob("skipper"); !errors.Is(err, ErrJobLocked) {
+		t.Fatalf("expected ErrJobLocked, got %v", err)
+	}
+	if s.IsRunning("skipper") {
+		t.Fatalf("job should not be marked running when lease is not acquired")
+	}
+}
+
+// countingFetcher 记录被调用的次数
+type countingFetcher struct {
+	name  string
+	calls int
+}
+
+func (c *countingFetcher) Name() string { return c.name }
+
+func (c *countingFetcher) FetchContext(ctx context.Context) ([]collector.NewsItem, error) {
+	c.calls++
+	return nil, nil
+}
+
+func TestReplicasCollectOncePerTick(t *testing.T) {
+	locker := &fakeLocker{held: map[string]bool{}}
+	f := &countingFetcher{name: "ashare"}
+	newReplica := func() *Scheduler {
+		s, err := New([]FetcherJob{{Fetcher: f, CronSpec: "0 0 1 1 *"}}, processor.NewSimpleProcessor(), nil, Options{Locker: locker})
+		if err != nil {
+			t.Fatalf("New error: %v", err)
+		}
+		return s
+	}
+	a, b := newReplica(), newReplica()
+	j, _ := a.Job("ashare")
+
+	// A 执行完并释放执行租约后，B 在同一周期内的 cron / 启动执行都应跳过
+	a.runFetcher(j, TriggerCron)
+	b.runFetcher(j, TriggerCron)
+	b.runFetcher(j, TriggerStartup)
+	if f.calls != 1 {
+		t.Fatalf("fetch calls = %d, want 1 per tick across replicas", f.calls)
+	}
+	if locker.held[lockKeyPrefix+"ashare"] {
+		t.Fatal("run lease should be released after the run")
+	}
+	// 手动触发不受调度周期限制
+	if _, err := b.RunJob("ashare"); err != nil {
+		t.Fatalf("RunJob error: %v", err)
+	}
+	if f.calls != 2 {
+		t.Fatalf("fetch calls = %d, want manual run to collect", f.calls)
+	}
+}
+
+func TestScheduleTick(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 17, 5, 0, time.Local)
+	cases := []struct {
+		spec       string
+		tick, next time.Time
+	}{
+		{"*/10 * * * *", time.Date(2026, 3, 4, 10, 10, 0, 0, time.Local), time.Date(2026, 3, 4, 10, 20, 0, 0, time.Local)},
+		{"0 8 * * *", time.Date(2026, 3, 4, 8, 0, 0, 0, time.Local), time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local)},
+		{"@every 1h", now.Truncate(time.Hour), now.Truncate(time.Hour).Add(time.Hour)},
+	}
+	for _, c := range cases {
+		tick, next, err := scheduleTick(c.spec, now)
+		if err != nil {
+			t.Fatalf("scheduleTick(%q) error: %v", c.spec, err)
+		}
+		if !tick.Equal(c.tick) || !next.Equal(c.next) {
+			t.Errorf("scheduleTick(%q) = %v, %v; want %v, %v", c.spec, tick, next, c.tick, c.next)
+		}
+	}
+}
+
+func TestStopWaitsForInFlightRun(t *testing.T) {
+	f := &blockingFetcher{name: "slow", started: make(chan struct{}), release: make(chan struct{})}
+	s, err := New([]FetcherJob{{Fetcher: f, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	if _, err := s.StartJob("slow"); err != nil {
+		t.Fatalf("StartJob error: %v", err)
+	}
+	<-f.started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop returned before in-flight run finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if _, err := s.RunJob("slow"); !errors.Is(err, ErrStopped) && !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("expected run to be rejected while stopping, got %v", err)
+	}
+
+	close(f.release)
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Stop error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Stop did not return after run finished")
+	}
+	if _, err := s.RunJob("slow"); !errors.Is(err, ErrStopped) {
+		t.Fatalf("expected ErrStopped after Stop, got %v", err)
+	}
+}
+
+func TestStopCancelsRunsAfterDeadline(t *testing.T) {
+	f := &blockingFetcher{name: "slow", started: make(chan struct{}), release: make(chan struct{})}
+	s, err := New([]FetcherJob{{Fetcher: f, CronSpec: "@every 1h"}}, processor.NewSimpleProcessor(), nil, Options{
+		Retry: RetryPolicy{MaxAttempts: 1},
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	if _, err := s.StartJob("slow"); err != nil {
+		t.Fatalf("StartJob error: %v", err)
+	}
+	<-f.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	// 超时后采集 context 被取消，进行中的任务应很快退出
+	deadline := time.Now().Add(time.Second)
+	for s.IsRunning("slow") {
+		if time.Now().After(deadline) {
+			t.Fatalf("run not cancelled after Stop deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
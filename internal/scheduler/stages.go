@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+// 调度器内置的处理阶段名称，另有 processor.StageNormalize / processor.StageDedup / processor.StageScore
+const (
+	StageFilter    = "filter"
+	StageEnrich    = "enrich"
+	StageTranslate = "translate"
+	StageTag       = "tag"
+	StageSummarize = "summarize"
+	StageCluster   = "cluster"
+)
+
+// builtinStages 内置阶段；依赖的服务未配置时阶段不做任何修改
+func (s *Scheduler) builtinStages() map[string]processor.Stage {
+	return map[string]processor.Stage{
+		processor.StageNormalize: processor.NormalizeStage(),
+		processor.StageDedup:     processor.DedupStage(),
+		processor.StageScore:     processor.ScoreStage(),
+		StageFilter: processor.NewStage(StageFilter, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			if s.opts.Filter == nil {
+				return items, 0
+			}
+			return s.opts.Filter.FilterItems(ctx, items), 0
+		}),
+		StageEnrich: processor.NewStage(StageEnrich, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			if s.opts.Enricher == nil {
+				return items, 0
+			}
+			return items, s.opts.Enricher.EnrichItems(ctx, items)
+		}),
+		StageTranslate: processor.NewStage(StageTranslate, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			return items, translateProcessed(ctx, items, s.opts.Languages)
+		}),
+		StageTag: processor.NewStage(StageTag, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			if s.opts.Tagger == nil {
+				return items, 0
+			}
+			return items, s.opts.Tagger.TagItems(ctx, items)
+		}),
+		StageSummarize: processor.NewStage(StageSummarize, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			if s.opts.Summarizer == nil {
+				return items, 0
+			}
+			return items, s.opts.Summarizer.SummarizeItems(ctx, items)
+		}),
+		StageCluster: processor.NewStage(StageCluster, func(ctx context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+			if s.opts.Clusterer == nil {
+				return items, 0
+			}
+			return items, s.opts.Clusterer.ClusterItems(ctx, items)
+		}),
+	}
+}
+
+// StageNames 返回已注册的处理阶段名称，供管理接口展示
+func (s *Scheduler) StageNames() []string {
+	names := make([]string, 0, len(s.stages))
+	for name := range s.stages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultPostStages 渠道未配置 stages 时，在默认处理器之后执行的阶段。
+// 过滤最先执行，被去掉的条目不再抓取预览与翻译；打标签与聚类在翻译之后进行：外文条目的中文标题也参与匹配
+func defaultPostStages(j FetcherJob) []string {
+	names := []string{StageFilter}
+	if j.Enrich {
+		names = append(names, StageEnrich)
+	}
+	names = append(names, StageTranslate, StageTag)
+	if j.Summarize {
+		names = append(names, StageSummarize)
+	}
+	if j.Cluster {
+		names = append(names, StageCluster)
+	}
+	return names
+}
+
+// validateStages 检查阶段名称均已注册
+func (s *Scheduler) validateStages(names []string) error {
+	for _, name := range names {
+		if _, ok := s.stages[name]; !ok {
+			return fmt.Errorf("unknown stage %q", name)
+		}
+	}
+	return nil
+}
+
+// pipeline 按名称组装流水线，未注册的阶段只记日志并跳过
+func (s *Scheduler) pipeline(job string, names []string) *processor.Pipeline {
+	stages := make([]processor.Stage, 0, len(names))
+	for _, name := range names {
+		st, ok := s.stages[name]
+		if !ok {
+			log.Printf("%s: skip unknown stage %q", job, name)
+			continue
+		}
+		stages = append(stages, st)
+	}
+	return processor.NewPipeline(stages...)
+}
+
+// process 执行任务的处理阶段并返回各阶段的统计：渠道配置了 stages 时按配置顺序执行，
+// 否则先执行默认处理器，再执行 defaultPostStages
+func (s *Scheduler) process(ctx context.Context, j FetcherJob, items []collector.NewsItem) ([]processor.ProcessedNews, []processor.StageStats) {
+	name := j.Fetcher.Name()
+	if len(j.Stages) > 0 {
+		return s.pipeline(name, j.Stages).Run(ctx, items)
+	}
+	processed, stats := s.processor.Run(ctx, items)
+	processed, post := s.pipeline(name, defaultPostStages(j)).Apply(ctx, processed)
+	return processed, append(stats, post...)
+}
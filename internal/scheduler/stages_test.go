@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+type countingClusterer struct{ calls int }
+
+func (c *countingClusterer) ClusterItems(_ context.Context, items []processor.ProcessedNews) int {
+	c.calls++
+	for i := range items {
+		items[i].StoryID = items[i].ID
+	}
+	return len(items)
+}
+
+func stageNames(stats []processor.StageStats) []string {
+	names := make([]string, len(stats))
+	for i, st := range stats {
+		names[i] = st.Stage
+	}
+	return names
+}
+
+func TestProcessDefaultAndConfiguredStages(t *testing.T) {
+	clusterer := &countingClusterer{}
+	dropHN := processor.NewStage("drop_hn", func(_ context.Context, items []processor.ProcessedNews) ([]processor.ProcessedNews, int) {
+		out := items[:0]
+		for _, it := range items {
+			if it.Title != "HN" {
+				out = append(out, it)
+			}
+		}
+		return out, 0
+	})
+	s, err := New(nil, processor.NewSimpleProcessor(), nil, Options{
+		Clusterer: clusterer,
+		Stages:    map[string]processor.Stage{"drop_hn": dropHN},
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	items := func() []collector.NewsItem {
+		return []collector.NewsItem{
+			{Title: "Go 1.24", URL: "https://go.dev/blog/go1.24", Source: "feed"},
+			{Title: "HN", URL: "https://news.ycombinator.com", Source: "feed"},
+		}
+	}
+
+	// 未配置 stages：默认处理器 + filter + translate + tag + cluster
+	out, stats := s.process(context.Background(), FetcherJob{Fetcher: namedFetcher("a"), Cluster: true}, items())
+	want := []string{"normalize", "dedup", StageFilter, StageTranslate, StageTag, StageCluster}
+	if got := stageNames(stats); !slices.Equal(got, want) {
+		t.Fatalf("default stages = %v, want %v", got, want)
+	}
+	if len(out) != 2 || out[0].StoryID == "" || stats[5].Modified != 2 {
+		t.Fatalf("cluster stage not applied: %+v %+v", out, stats[5])
+	}
+
+	// 配置了 stages：按顺序执行，未列出的默认阶段不执行，未注册的名称跳过
+	clusterer.calls = 0
+	job := FetcherJob{Fetcher: namedFetcher("b"), Cluster: true, Stages: []string{"dedup", "drop_hn", "missing"}}
+	out, stats = s.process(context.Background(), job, items())
+	if got := stageNames(stats); len(got) != 2 || got[0] != "dedup" || got[1] != "drop_hn" {
+		t.Fatalf("configured stages = %v", got)
+	}
+	if len(out) != 1 || stats[1].Dropped != 1 || clusterer.calls != 0 {
+		t.Fatalf("out=%+v stats=%+v cluster calls=%d", out, stats, clusterer.calls)
+	}
+}
+
+func TestValidateChannelStages(t *testing.T) {
+	// 保存前校验不应触发 Prepare（注册数据源、建表等）
+	prepare := func(storage.Channel) error {
+		t.Error("ValidateChannel should not call Prepare")
+		return nil
+	}
+	s, err := New(nil, processor.NewSimpleProcessor(), nil, Options{Factories: testFactories(), Prepare: prepare})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	ch := storage.Channel{Code: "a", FetcherType: "stub", CronSpec: "0 * * * *"}
+	for _, tc := range []struct {
+		stages []any
+		ok     bool
+	}{
+		{nil, true},
+		{[]any{"normalize", "dedup", "translate"}, true},
+		{[]any{"normalize", "translate"}, false},
+		{[]any{"dedup", "unknown"}, false},
+	} {
+		ch.Config = map[string]any{}
+		if tc.stages != nil {
+			ch.Config["stages"] = tc.stages
+		}
+		if err := s.ValidateChannel(ch); (err == nil) != tc.ok {
+			t.Errorf("stages %v: err = %v, want ok=%v", tc.stages, err, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+// translateProcessed 将每条数据的原文（无原文时为标题 / 介绍本身）翻译为 langs 中的各语言，写入 Translations。
+// 译文与原文相同（已是目标语言或翻译失败）时不写入，查询时回退为原文；翻译使用处理阶段的 ctx（processTimeout，与采集超时分开计算），到期后剩余条目保留原文。
+// 返回写入了译文的条目数
+func translateProcessed(ctx context.Context, items []processor.ProcessedNews, langs []string) int {
+	if len(langs) == 0 || len(items) == 0 {
+		return 0
+	}
+	titles := make([]string, len(items))
+	descs := make([]string, len(items))
+	for i, it := range items {
+		titles[i] = collector.FirstNonEmpty(it.OriginalTitle, it.Title)
+		descs[i] = collector.FirstNonEmpty(it.OriginalDescription, it.Description)
+	}
+	for _, lang := range langs {
+		if lang == "zh" {
+			continue
+		}
+		tTitles := collector.TranslateAll(ctx, nil, titles, lang)
+		tDescs := collector.TranslateAll(ctx, nil, descs, lang)
+		for i := range items {
+			fields := map[string]string{}
+			if tTitles[i] != titles[i] {
+				fields["title"] = tTitles[i]
+			}
+			if tDescs[i] != descs[i] {
+				fields["description"] = tDescs[i]
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			if items[i].Translations == nil {
+				items[i].Translations = make(map[string]map[string]string)
+			}
+			items[i].Translations[lang] = fields
+		}
+	}
+	n := 0
+	for _, it := range items {
+		if len(it.Translations) > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+// upperTranslator 将文本转为大写，模拟翻译为 "en"
+type upperTranslator struct{}
+
+func (upperTranslator) Name() string { return "upper" }
+
+func (upperTranslator) Translate(ctx context.Context, client *http.Client, text, target string) (string, error) {
+	return strings.ToUpper(text), nil
+}
+
+func TestTranslateProcessedUsesOriginalText(t *testing.T) {
+	collector.SetTranslators([]collector.Translator{upperTranslator{}})
+	t.Cleanup(func() { collector.SetTranslators(nil) })
+
+	items := []processor.ProcessedNews{
+		{Title: "译文", Description: "译文", OriginalTitle: "hello", OriginalDescription: "world"},
+		{Title: "SAME", Description: "SAME"},
+	}
+	translateProcessed(context.Background(), items, []string{"zh", "en"})
+
+	got := items[0].Translations["en"]
+	if got["title"] != "HELLO" || got["description"] != "WORLD" {
+		t.Fatalf("translations = %v, want HELLO / WORLD", items[0].Translations)
+	}
+	if _, ok := items[0].Translations["zh"]; ok {
+		t.Fatal("zh is the default rendering and should not be stored in translations")
+	}
+	// 译文与原文相同时不写入
+	if items[1].Translations != nil {
+		t.Fatalf("unchanged text should not be stored: %v", items[1].Translations)
+	}
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"gorm.io/gorm"
+)
+
+// DataMigration 记录已执行过的一次性数据迁移，避免每次启动重复执行
+type DataMigration struct {
+	Name      string    `gorm:"primaryKey;size:64" json:"name"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// canonicalURLBackfill 处理器开始规范化链接之前入库的条目，url 仍是原始链接（带 utm 参数、末尾 "/" 等）
+const canonicalURLBackfill = "canonical_urls"
+
+// runDataMigrations 依次执行尚未执行过的一次性数据迁移
+func runDataMigrations(db *gorm.DB) error {
+	migrations := []struct {
+		name string
+		run  func(*gorm.DB) error
+	}{
+		{canonicalURLBackfill, backfillCanonicalURLs},
+	}
+	for _, m := range migrations {
+		var done DataMigration
+		err := db.Where("name = ?", m.name).First(&done).Error
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("read data migration %s: %w", m.name, err)
+		}
+		if err := m.run(db); err != nil {
+			return fmt.Errorf("data migration %s: %w", m.name, err)
+		}
+		if err := db.Create(&DataMigration{Name: m.name, AppliedAt: time.Now()}).Error; err != nil {
+			return fmt.Errorf("record data migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// backfillCanonicalURLs 将所有分表（含单独建表的订阅源）中已有条目的 url 改写为规范形式，
+// 使 SaveBatch 按 url 能匹配到这些旧记录，而不是再插入一条重复数据。ID 保持不变，SaveBatch 已按库中的 ID 处理已有记录。
+// 同一渠道已有规范链接的记录（上线后已重复插入）时跳过该行
+func backfillCanonicalURLs(db *gorm.DB) error {
+	var tables []string
+	if err := db.Raw(`SELECT DISTINCT table_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name LIKE 'news\_%' AND column_name = 'url'
+		ORDER BY table_name`).Scan(&tables).Error; err != nil {
+		return fmt.Errorf("list news tables: %w", err)
+	}
+	for _, tbl := range tables {
+		updated, skipped := 0, 0
+		// 按 (id, source) 分页：共享表中同一 id 可能对应多个渠道
+		var lastID, lastSource string
+		for {
+			var rows []struct {
+				ID     string
+				URL    string
+				Source string
+			}
+			if err := db.Table(tbl).Select("id", "url", "source").Where("(id, source) > (?, ?)", lastID, lastSource).
+				Order("id").Order("source").Limit(1000).Find(&rows).Error; err != nil {
+				return fmt.Errorf("backfill %s: %w", tbl, err)
+			}
+			if len(rows) == 0 {
+				break
+			}
+			for _, r := range rows {
+				link := processor.CanonicalURL(r.Source, r.URL)
+				if link == r.URL {
+					continue
+				}
+				res := db.Exec(fmt.Sprintf(`UPDATE %s SET url = ? WHERE id = ? AND source = ?
+					AND NOT EXISTS (SELECT 1 FROM %s o WHERE o.url = ? AND o.source = ?)`, tbl, tbl),
+					link, r.ID, r.Source, link, r.Source)
+				if res.Error != nil || res.RowsAffected == 0 {
+					skipped++
+					continue
+				}
+				updated++
+			}
+			last := rows[len(rows)-1]
+			lastID, lastSource = last.ID, last.Source
+		}
+		if updated > 0 || skipped > 0 {
+			log.Printf("storage: canonicalized %d urls in %s, skipped %d", updated, tbl, skipped)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// 渠道状态，disabled 的渠道不会被调度采集
+const (
+	ChannelActive   = "active"
+	ChannelDisabled = "disabled"
+)
+
+// Channel 描述一个数据源，例如 weibo / zhihu / github；FetcherType 非空时调度器据此构建采集任务
+type Channel struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	Code    string `gorm:"size:64;uniqueIndex" json:"code"` // 例如: github, zhihu
+	Name    string `gorm:"size:128" json:"name"`
+	BaseURL string `gorm:"size:256" json:"baseUrl"`
+	Status  string `gorm:"size:32;index" json:"status"` // active / disabled
+
+	FetcherType string `gorm:"size:64" json:"fetcherType"` // 采集器类型，如 baidu_hot；为空表示该渠道不采集
+	CronSpec    string `gorm:"size:64" json:"cronSpec"`    // 标准 5 段 cron 表达式或 @every 1h 形式
+	TimeoutSec  int    `json:"timeoutSec"`                 // 单次采集超时（秒），0 表示使用默认值
+	MaxItems    int    `json:"maxItems"`                   // 单次最多入库条数，0 表示不限制
+	// Config 采集器专属参数，如 X 趋势的 {"regions": ["worldwide", "japan"]}
+	Config datatypes.JSONMap `gorm:"type:jsonb" json:"config"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SeedChannels 插入缺失的默认渠道，并为旧版本创建、尚未配置采集器的渠道补齐采集参数；
+// 管理员已修改过的配置不会被覆盖。
+func (s *Store) SeedChannels(defaults []Channel) error {
+	for _, def := range defaults {
+		ch := def
+		if err := s.DB.Where("code = ?", def.Code).FirstOrCreate(&ch).Error; err != nil {
+			return err
+		}
+		if ch.FetcherType != "" || def.FetcherType == "" {
+			continue
+		}
+		if err := s.DB.Model(&ch).Updates(map[string]any{
+			"fetcher_type": def.FetcherType,
+			"cron_spec":    def.CronSpec,
+			"timeout_sec":  def.TimeoutSec,
+			"max_items":    def.MaxItems,
+			"config":       def.Config,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigStrings 读取 Config 中的字符串列表参数，忽略空白与非字符串元素；也接受逗号分隔的单个字符串
+func (c Channel) ConfigStrings(key string) []string {
+	var out []string
+	add := func(v string) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	switch v := c.Config[key].(type) {
+	case []any:
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				add(s)
+			}
+		}
+	case []string:
+		for _, s := range v {
+			add(s)
+		}
+	case string:
+		for _, s := range strings.Split(v, ",") {
+			add(s)
+		}
+	}
+	return out
+}
+
+// ConfigInt 读取 Config 中的整数参数；JSON 数字解码为 float64，也接受数字字符串，缺失或非法时返回 def
+func (c Channel) ConfigInt(key string, def int) int {
+	switch v := c.Config[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// ConfigBool 读取布尔配置项，支持 JSON 布尔值与 "true"/"1" 等字符串，缺失或无法解析时返回 false
+func (c Channel) ConfigBool(key string) bool {
+	switch v := c.Config[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(strings.TrimSpace(v))
+		return b
+	case float64:
+		return v != 0
+	}
+	return false
+}
+
+// ListChannels 按 ID 顺序返回所有渠道
+func (s *Store) ListChannels() ([]Channel, error) {
+	var list []Channel
+	if err := s.DB.Order("id").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetChannel 按 code 返回渠道，不存在时返回 gorm.ErrRecordNotFound
+func (s *Store) GetChannel(code string) (*Channel, error) {
+	var ch Channel
+	if err := s.DB.Where("code = ?", code).First(&ch).Error; err != nil {
+		return nil, err
+	}
+	return &ch, nil
+}
+
+// CreateChannel 新增渠道
+func (s *Store) CreateChannel(ch *Channel) error {
+	return s.DB.Create(ch).Error
+}
+
+// SaveChannel 按 ID 更新渠道的全部字段
+func (s *Store) SaveChannel(ch *Channel) error {
+	return s.DB.Save(ch).Error
+}
+
+// DeleteChannel 按 code 删除渠道配置，已采集的数据保留；返回是否删除了记录
+func (s *Store) DeleteChannel(code string) (bool, error) {
+	res := s.DB.Where("code = ?", code).Delete(&Channel{})
+	return res.RowsAffected > 0, res.Error
+}
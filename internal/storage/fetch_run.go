@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"gorm.io/datatypes"
+)
+
+// 采集执行结果状态
+const (
+	FetchRunRunning     = "running"      // 执行中，结束后更新为下列状态之一
+	FetchRunSuccess     = "success"      // 正常返回且有数据
+	FetchRunEmpty       = "empty"        // 正常返回但 0 条数据，通常意味着页面结构变化或被限流
+	FetchRunSkipped     = "skipped"      // 采集器主动跳过（如 A 股休市），不计入失败
+	FetchRunCircuitOpen = "circuit_open" // 熔断中未实际访问数据源，不计入失败
+	FetchRunError       = "error"
+	FetchRunPanic       = "panic"
+)
+
+// fetchRunRetention 采集记录保留时长，超出部分由 PruneFetchRuns 清理
+const fetchRunRetention = 30 * 24 * time.Hour
+
+// FetchRun 记录每一次采集执行，用于排查数据源何时失效、耗时多久、为何失败
+type FetchRun struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Fetcher    string    `gorm:"size:64;index:idx_fetch_runs_fetcher_started,priority:1" json:"fetcher"`
+	Trigger    string    `gorm:"size:16" json:"trigger"` // cron / startup / manual
+	StartedAt  time.Time `gorm:"index:idx_fetch_runs_fetcher_started,priority:2;index" json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	DurationMs int64     `json:"durationMs"`
+	Fetched    int       `json:"fetched"`
+	Saved      int       `json:"saved"`
+	Attempts   int       `json:"attempts"` // 含重试在内的实际尝试次数
+	Status     string    `gorm:"size:16;index" json:"status"`
+	Error      string    `gorm:"type:text" json:"error"`
+	Panic      bool      `json:"panic"`
+	// Stages 各处理阶段的输入条数、去掉与修改的条数及耗时
+	Stages datatypes.JSONSlice[processor.StageStats] `gorm:"type:jsonb" json:"stages,omitempty"`
+}
+
+// Finish 记录结束时间与耗时，并根据错误/数量推导 Status；Status 已被设置为 skipped / circuit_open 时保持不变
+func (r *FetchRun) Finish(end time.Time) {
+	r.FinishedAt = end
+	r.DurationMs = end.Sub(r.StartedAt).Milliseconds()
+	switch {
+	case r.Panic:
+		r.Status = FetchRunPanic
+	case r.Status == FetchRunSkipped, r.Status == FetchRunCircuitOpen:
+	case r.Error != "":
+		r.Status = FetchRunError
+	case r.Fetched == 0:
+		r.Status = FetchRunEmpty
+	default:
+		r.Status = FetchRunSuccess
+	}
+}
+
+// FetchRunSummary 单个数据源的健康概况
+type FetchRunSummary struct {
+	Fetcher             string     `json:"fetcher"`
+	LastRunAt           time.Time  `json:"lastRunAt"`
+	LastStatus          string     `json:"lastStatus"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"` // 最近连续的 error/panic/empty 次数（skipped / circuit_open 不计）
+}
+
+// SaveFetchRun 写入或更新一条采集记录（ID 为 0 时插入，否则按 ID 更新）
+func (s *Store) SaveFetchRun(run *FetchRun) error {
+	return s.DB.Save(run).Error
+}
+
+// GetFetchRun 按 ID 返回采集记录，不存在时返回 gorm.ErrRecordNotFound
+func (s *Store) GetFetchRun(id uint) (*FetchRun, error) {
+	var run FetchRun
+	if err := s.DB.First(&run, id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListFetchRuns 按开始时间倒序分页返回采集记录；fetcher 为空时返回所有数据源
+func (s *Store) ListFetchRuns(fetcher string, page, pageSize int) ([]FetchRun, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 200 {
+		pageSize = 50
+	}
+	q := s.DB.Model(&FetchRun{})
+	if fetcher != "" {
+		q = q.Where("fetcher = ?", fetcher)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []FetchRun
+	if err := q.Order("started_at DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// SummarizeFetchRuns 返回每个数据源最近一次执行、最近一次成功时间与连续失败次数
+func (s *Store) SummarizeFetchRuns() ([]FetchRunSummary, error) {
+	var names []string
+	if err := s.DB.Model(&FetchRun{}).Distinct("fetcher").Order("fetcher").Pluck("fetcher", &names).Error; err != nil {
+		return nil, err
+	}
+	out := make([]FetchRunSummary, 0, len(names))
+	for _, name := range names {
+		var lastSuccess FetchRun
+		var lastSuccessAt *time.Time
+		q := s.DB.Where("fetcher = ? AND status = ?", name, FetchRunSuccess).Order("started_at DESC").Limit(1).Find(&lastSuccess)
+		if q.Error != nil {
+			return nil, q.Error
+		}
+		if q.RowsAffected > 0 {
+			t := lastSuccess.StartedAt
+			lastSuccessAt = &t
+		}
+
+		// 只需要最近一次成功之后的记录即可算出连续失败次数
+		var recent []FetchRun
+		rq := s.DB.Where("fetcher = ?", name)
+		if lastSuccessAt != nil {
+			rq = rq.Where("started_at >= ?", *lastSuccessAt)
+		}
+		if err := rq.Order("started_at DESC").Limit(1000).Find(&recent).Error; err != nil {
+			return nil, err
+		}
+		if len(recent) == 0 {
+			continue
+		}
+		sum := FetchRunSummary{
+			Fetcher:       name,
+			LastRunAt:     recent[0].StartedAt,
+			LastStatus:    recent[0].Status,
+			LastError:     recent[0].Error,
+			LastSuccessAt: lastSuccessAt,
+		}
+		for _, r := range recent {
+			if r.Status == FetchRunSuccess {
+				break
+			}
+			switch r.Status {
+			case FetchRunSkipped, FetchRunCircuitOpen, FetchRunRunning:
+			default:
+				sum.ConsecutiveFailures++
+			}
+		}
+		out = append(out, sum)
+	}
+	return out, nil
+}
+
+// PruneFetchRuns 删除超过保留期的采集记录
+func (s *Store) PruneFetchRuns() error {
+	return s.DB.Where("started_at < ?", time.Now().Add(-fetchRunRetention)).Delete(&FetchRun{}).Error
+}
@@ -0,0 +1,75 @@
+package storage
+
+import "time"
+
+// 过滤规则的动作：exclude 去掉命中的条目；渠道存在 include 规则时只保留命中任一 include 规则的条目
+const (
+	FilterInclude = "include"
+	FilterExclude = "exclude"
+)
+
+// 过滤规则的匹配方式
+const (
+	FilterKeyword = "keyword"
+	FilterRegex   = "regex"
+)
+
+// 过滤规则匹配的字段
+const (
+	FilterFieldTitle = "title" // 标题与翻译前的原标题（默认）
+	FilterFieldAll   = "all"   // 另外包括介绍与原介绍
+)
+
+// FilterRule 入库前按渠道过滤条目的规则，匹配时忽略大小写与全角 / 半角差异
+type FilterRule struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	// Channel 渠道 code（即条目的 source），为空表示对所有渠道生效
+	Channel   string `gorm:"size:64;index" json:"channel"`
+	Action    string `gorm:"size:16" json:"action"`    // include / exclude
+	MatchType string `gorm:"size:16" json:"matchType"` // keyword / regex
+	Pattern   string `gorm:"size:512" json:"pattern"`
+	Field     string `gorm:"size:16" json:"field"` // title / all
+	Enabled   bool   `json:"enabled"`
+	Note      string `gorm:"size:256" json:"note"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ListFilterRules 按 ID 顺序返回过滤规则；channel 非空时只返回该渠道与全局（channel 为空）的规则
+func (s *Store) ListFilterRules(channel string) ([]FilterRule, error) {
+	var list []FilterRule
+	db := s.DB.Order("id")
+	if channel != "" {
+		db = db.Where("channel = ? OR channel = ''", channel)
+	}
+	if err := db.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetFilterRule 按 ID 返回过滤规则，不存在时返回 gorm.ErrRecordNotFound
+func (s *Store) GetFilterRule(id uint) (*FilterRule, error) {
+	var r FilterRule
+	if err := s.DB.First(&r, id).Error; err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// CreateFilterRule 新增过滤规则
+func (s *Store) CreateFilterRule(r *FilterRule) error {
+	return s.DB.Create(r).Error
+}
+
+// SaveFilterRule 按 ID 更新过滤规则的全部字段
+func (s *Store) SaveFilterRule(r *FilterRule) error {
+	return s.DB.Save(r).Error
+}
+
+// DeleteFilterRule 按 ID 删除过滤规则，返回是否删除了记录
+func (s *Store) DeleteFilterRule(id uint) (bool, error) {
+	res := s.DB.Delete(&FilterRule{}, id)
+	return res.RowsAffected > 0, res.Error
+}
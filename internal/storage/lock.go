@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseLockScript 仅当锁仍由自己持有（token 一致）时才删除，避免误删其它副本在 TTL 过期后重新获得的锁
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// TryLock 基于 Redis SET NX PX 获取一个带 TTL 的租约，用于多副本部署时保证同一任务只有一个副本在执行。
+// 获取成功返回 unlock；已被其它副本持有时 ok 为 false；未配置 Redis 时总是成功。
+func (s *Store) TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error) {
+	if s.Redis == nil {
+		return func() {}, true, nil
+	}
+	token, err := newLockToken()
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err = s.Redis.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("redis lock %s: %w", key, err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := releaseLockScript.Run(ctx, s.Redis, []string{key}, token).Err(); err != nil && !errors.Is(err, redis.Nil) {
+			log.Printf("redis unlock %s error: %v", key, err)
+		}
+	}, true, nil
+}
+
+// newLockToken 生成 "主机名-进程号-随机串" 形式的持有者标识，便于在 Redis 中排查锁归属
+func newLockToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b)), nil
+}
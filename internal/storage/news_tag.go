@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewsTag 条目的主题标签（news_tags 表），冗余保存日期，统计标签数量时无需跨分表查询。
+// 不同数据源收录同一链接时新闻 ID 相同，标签按 (新闻 ID, 数据源) 分别保存
+type NewsTag struct {
+	NewsID        string    `gorm:"primaryKey;size:40" json:"newsId"`
+	Source        string    `gorm:"primaryKey;size:64;index" json:"source"`
+	Tag           string    `gorm:"primaryKey;size:32;index" json:"tag"`
+	PublishedDate string    `gorm:"size:10;index" json:"publishedDate"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TagClassification 大模型分类结果的缓存：key 为条目 ID，Tags 为逗号分隔的标签，为空表示没有贴切的标签
+type TagClassification struct {
+	ID        string    `gorm:"primaryKey;size:40" json:"id"`
+	Model     string    `gorm:"size:64" json:"model"`
+	Tags      string    `gorm:"size:256" json:"tags"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TagCount 标签及其条目数
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// replaceNewsTags 用 rows 替换该数据源下 ids 对应条目的全部标签，不影响其它数据源收录的同一链接
+func (s *Store) replaceNewsTags(source string, ids []string, rows []NewsTag) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source = ? AND news_id IN ?", source, ids).Delete(&NewsTag{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+}
+
+// TagsByNews 返回该数据源下条目的标签：新闻 ID → 按名称排序的标签
+func (s *Store) TagsByNews(source string, newsIDs []string) (map[string][]string, error) {
+	out := make(map[string][]string, len(newsIDs))
+	if len(newsIDs) == 0 {
+		return out, nil
+	}
+	var rows []NewsTag
+	if err := s.DB.Select("news_id", "tag").Where("source = ? AND news_id IN ?", source, newsIDs).Order("tag").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		out[r.NewsID] = append(out[r.NewsID], r.Tag)
+	}
+	return out, nil
+}
+
+// attachTags 为列表中的条目填写 Tags（按条目的数据源分别查询），查询失败时保持为空
+func (s *Store) attachTags(list []News) {
+	bySource := make(map[string][]string)
+	for _, n := range list {
+		bySource[n.Source] = append(bySource[n.Source], n.ID)
+	}
+	for source, ids := range bySource {
+		tags, err := s.TagsByNews(source, ids)
+		if err != nil {
+			continue
+		}
+		for i := range list {
+			if list[i].Source == source {
+				list[i].Tags = tags[list[i].ID]
+			}
+		}
+	}
+}
+
+// applyTagFilter 只保留带有指定标签的条目，按 (新闻 ID, 数据源) 匹配
+func applyTagFilter(db *gorm.DB, tag string) *gorm.DB {
+	if tag == "" {
+		return db
+	}
+	return db.Where("(id, source) IN (SELECT news_id, source FROM news_tags WHERE tag = ?)", tag)
+}
+
+// channelSources 返回渠道列表中包含的数据源：金融渠道合并了 gold 与 ashare，见 ListNews
+func channelSources(channel string) []string {
+	if channel == "gold" {
+		return []string{"gold", "ashare"}
+	}
+	return []string{channel}
+}
+
+// TagFacets 按渠道与日期（均可为空）统计各标签的条目数，按数量倒序，最多 50 个；结果缓存 5 分钟
+func (s *Store) TagFacets(channel, date string) ([]TagCount, error) {
+	ctx := context.Background()
+	cacheKey := fmt.Sprintf("news:tags:%s:%s", channel, date)
+	if s.Redis != nil {
+		if bs, err := s.Redis.Get(ctx, cacheKey).Bytes(); err == nil {
+			var cached []TagCount
+			if err := json.Unmarshal(bs, &cached); err == nil {
+				return cached, nil
+			}
+		}
+	}
+	list := make([]TagCount, 0)
+	db := s.DB.Model(&NewsTag{}).Select("tag, COUNT(*) AS count")
+	if channel != "" {
+		db = db.Where("source IN ?", channelSources(channel))
+	}
+	if date != "" {
+		db = db.Where("published_date = ?", date)
+	}
+	if err := db.Group("tag").Order("count DESC").Order("tag").Limit(50).Scan(&list).Error; err != nil {
+		return nil, err
+	}
+	if s.Redis != nil {
+		if bs, err := json.Marshal(list); err == nil {
+			_ = s.Redis.Set(ctx, cacheKey, bs, 5*time.Minute).Err()
+		}
+	}
+	return list, nil
+}
+
+// GetTagClassifications 返回已分类条目的标签：新闻 ID → 标签（可能为空），查询失败时返回空 map
+func (s *Store) GetTagClassifications(ctx context.Context, ids []string) map[string][]string {
+	out := make(map[string][]string, len(ids))
+	if len(ids) == 0 {
+		return out
+	}
+	var rows []TagClassification
+	if err := s.DB.WithContext(ctx).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return out
+	}
+	for _, r := range rows {
+		tags := []string{}
+		if r.Tags != "" {
+			tags = strings.Split(r.Tags, ",")
+		}
+		out[r.ID] = tags
+	}
+	return out
+}
+
+// SaveTagClassification 写入分类结果缓存，写入失败只影响缓存命中，不返回错误
+func (s *Store) SaveTagClassification(ctx context.Context, id, model string, tags []string) {
+	c := TagClassification{ID: id, Model: model, Tags: strings.Join(tags, ","), CreatedAt: time.Now()}
+	_ = s.DB.WithContext(ctx).Save(&c).Error
+}
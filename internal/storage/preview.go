@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// LinkPreview 链接预览缓存：key 为条目 ID（URL 的哈希），每个链接只抓取一次。
+// Data 为空表示 robots.txt 禁止抓取或页面不可用（如 4xx、非 HTML），之后不再重试
+type LinkPreview struct {
+	ID        string            `gorm:"primaryKey;size:40" json:"id"`
+	URL       string            `gorm:"size:1024" json:"url"`
+	Data      datatypes.JSONMap `gorm:"type:jsonb" json:"data"`
+	FetchedAt time.Time         `json:"fetchedAt"`
+}
+
+// GetPreviews 批量查询已抓取过的链接预览；返回的 map 中存在 key 即表示已抓取过，值可能为 nil
+func (s *Store) GetPreviews(ctx context.Context, ids []string) map[string]map[string]any {
+	out := make(map[string]map[string]any, len(ids))
+	if len(ids) == 0 {
+		return out
+	}
+	var rows []LinkPreview
+	if err := s.DB.WithContext(ctx).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return out
+	}
+	for _, r := range rows {
+		out[r.ID] = r.Data
+	}
+	return out
+}
+
+// SavePreview 写入链接预览，data 为 nil 时记录为不可抓取；写入失败只会导致下次重新抓取，不返回错误
+func (s *Store) SavePreview(ctx context.Context, id, url string, data map[string]any) {
+	p := LinkPreview{ID: id, URL: url, FetchedAt: time.Now()}
+	if data != nil {
+		p.Data = make(datatypes.JSONMap, len(data))
+		for k, v := range data {
+			if str, ok := v.(string); ok {
+				v = toValidUTF8(str)
+			}
+			p.Data[k] = v
+		}
+	}
+	_ = s.DB.WithContext(ctx).Save(&p).Error
+}
+
+// AmbiguousNewsError 未指定渠道而多个渠道都收录了该链接（条目 ID 相同）时由 GetNews 返回
+type AmbiguousNewsError struct {
+	Sources []string
+}
+
+func (e *AmbiguousNewsError) Error() string {
+	return fmt.Sprintf("news id matches multiple sources: %s", strings.Join(e.Sources, ", "))
+}
+
+// GetNews 按 ID 查找条目，不存在时返回 gorm.ErrRecordNotFound。
+// source 不为空时只在该渠道中查找；为空时一次查询所有分表，恰好一个渠道收录时返回该条目，多个渠道收录时返回 *AmbiguousNewsError
+func (s *Store) GetNews(id, source string) (*News, error) {
+	var t newsTarget
+	if source != "" {
+		var ok bool
+		if t, ok = s.target(source); !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+	} else {
+		var err error
+		if t, err = s.resolveNews(id); err != nil {
+			return nil, err
+		}
+	}
+	var n News
+	if err := t.scope(s.DB).Where("id = ?", id).Limit(1).Find(&n).Error; err != nil {
+		return nil, err
+	}
+	if n.ID == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	list := []News{n}
+	s.attachTags(list)
+	return &list[0], nil
+}
+
+// resolveNews 用一条 UNION ALL 查询找出收录了该条目的表与渠道
+func (s *Store) resolveNews(id string) (newsTarget, error) {
+	tables := s.allTables()
+	parts := make([]string, 0, len(tables))
+	args := make([]any, 0, len(tables)*2)
+	for _, tbl := range tables {
+		parts = append(parts, fmt.Sprintf("SELECT ? AS tbl, source FROM %s WHERE id = ?", tbl))
+		args = append(args, tbl, id)
+	}
+	var rows []struct {
+		Tbl    string
+		Source string
+	}
+	if err := s.DB.Raw(strings.Join(parts, " UNION ALL "), args...).Scan(&rows).Error; err != nil {
+		return newsTarget{}, err
+	}
+	switch len(rows) {
+	case 0:
+		return newsTarget{}, gorm.ErrRecordNotFound
+	case 1:
+		r := rows[0]
+		return newsTarget{table: r.Tbl, source: r.Source, shared: r.Tbl == sharedFeedTable}, nil
+	}
+	sources := make([]string, 0, len(rows))
+	for _, r := range rows {
+		sources = append(sources, r.Source)
+	}
+	sort.Strings(sources)
+	return newsTarget{}, &AmbiguousNewsError{Sources: sources}
+}
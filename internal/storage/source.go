@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// sharedFeedTable 运行时配置的渠道（订阅源、声明式抓取）默认共用的表，按 source 列区分渠道；
+// 主键为 (id, source)，不同渠道收录同一链接时各保留一行，需要完全隔离时可为渠道单独建表 news_<code>
+const sharedFeedTable = "news_feed"
+
+var sourceCodeRe = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// newsTarget 某个 source 的数据所在的表；shared 为 true 时表内混有其它渠道，查询需按 source 过滤
+type newsTarget struct {
+	table  string
+	source string
+	shared bool
+}
+
+// scope 返回限定到该 source 的查询
+func (t newsTarget) scope(db *gorm.DB) *gorm.DB {
+	db = db.Table(t.table)
+	if t.shared {
+		db = db.Where("source = ?", t.source)
+	}
+	return db
+}
+
+// CheckSource 校验数据源能否注册（代码格式、未被固定频道占用），不建表也不修改注册信息，供保存渠道前校验
+func CheckSource(source string, dedicated bool) error {
+	if !sourceCodeRe.MatchString(source) {
+		return fmt.Errorf("invalid source %q", source)
+	}
+	if _, ok := sourceToTable[source]; ok {
+		return fmt.Errorf("source %q is reserved", source)
+	}
+	if dedicated && "news_"+source == sharedFeedTable {
+		return fmt.Errorf("source %q cannot use a dedicated table", source)
+	}
+	return nil
+}
+
+// RegisterSource 注册运行时配置的数据源，使其数据可以写入与查询。
+// dedicated 为 true 时为其单独建表 news_<source>，否则写入共享表 news_feed。可重复调用，后一次覆盖前一次；
+// 与已注册的表相同时直接返回，定时重载渠道时不会重复建表检查列
+func (s *Store) RegisterSource(source string, dedicated bool) error {
+	if err := CheckSource(source, dedicated); err != nil {
+		return err
+	}
+	tbl := sharedFeedTable
+	if dedicated {
+		tbl = "news_" + source
+	}
+	s.sourcesMu.RLock()
+	registered, ok := s.sources[source]
+	s.sourcesMu.RUnlock()
+	if ok && registered == tbl {
+		return nil
+	}
+	if dedicated {
+		if err := ensureNewsTable(s.DB, tbl); err != nil {
+			return err
+		}
+	}
+	s.sourcesMu.Lock()
+	if s.sources == nil {
+		s.sources = make(map[string]string)
+	}
+	s.sources[source] = tbl
+	s.sourcesMu.Unlock()
+	return nil
+}
+
+// ensureNewsTable 按 news 的结构建分表；表已存在时补齐 News 后来新增的列（CREATE TABLE ... LIKE 不会同步新列）
+func ensureNewsTable(db *gorm.DB, tbl string) error {
+	if err := db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (LIKE news INCLUDING ALL)", tbl)).Error; err != nil {
+		return fmt.Errorf("create table %s: %w", tbl, err)
+	}
+	m := db.Table(tbl).Migrator()
+	for _, col := range newsColumns(db) {
+		if m.HasColumn(&News{}, col) {
+			continue
+		}
+		if err := m.AddColumn(&News{}, col); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", tbl, col, err)
+		}
+	}
+	return nil
+}
+
+// ensureSharedFeedKeys 将共享表的主键与 url 唯一索引改为带 source 的组合键；
+// CREATE TABLE ... LIKE 复制的是 news 的主键 (id) 与 url 唯一索引，按它们查找会命中其它渠道的记录
+func ensureSharedFeedKeys(db *gorm.DB) error {
+	if err := ensurePrimaryKey(db, sharedFeedTable, "id", "source"); err != nil {
+		return err
+	}
+	var urlIndexes []string
+	if err := db.Raw(`SELECT c.relname FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = ?::regclass AND i.indisunique AND NOT i.indisprimary AND i.indnatts = 1 AND a.attname = 'url'`,
+		sharedFeedTable).Scan(&urlIndexes).Error; err != nil {
+		return fmt.Errorf("read url indexes of %s: %w", sharedFeedTable, err)
+	}
+	for _, idx := range urlIndexes {
+		if err := db.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", idx)).Error; err != nil {
+			return fmt.Errorf("drop index %s: %w", idx, err)
+		}
+	}
+	if err := db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS idx_%s_url_source ON %s (url, source)", sharedFeedTable, sharedFeedTable)).Error; err != nil {
+		return fmt.Errorf("create url index of %s: %w", sharedFeedTable, err)
+	}
+	return nil
+}
+
+// newsColumns 返回 News 模型的全部列名
+func newsColumns(db *gorm.DB) []string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&News{}); err != nil {
+		return nil
+	}
+	return stmt.Schema.DBNames
+}
+
+// target 返回 source 对应的表；未知 source 返回 false
+func (s *Store) target(source string) (newsTarget, bool) {
+	if t, ok := sourceToTable[source]; ok {
+		return newsTarget{table: t, source: source}, true
+	}
+	s.sourcesMu.RLock()
+	tbl, ok := s.sources[source]
+	s.sourcesMu.RUnlock()
+	if !ok {
+		return newsTarget{}, false
+	}
+	return newsTarget{table: tbl, source: source, shared: tbl == sharedFeedTable}, true
+}
+
+// allTables 返回所有分表（固定频道、共享订阅表与单独建表的订阅源），用于不限渠道的合并查询
+func (s *Store) allTables() []string {
+	set := map[string]struct{}{sharedFeedTable: {}}
+	for _, t := range sourceToTable {
+		set[t] = struct{}{}
+	}
+	s.sourcesMu.RLock()
+	for _, t := range s.sources {
+		set[t] = struct{}{}
+	}
+	s.sourcesMu.RUnlock()
+	tables := make([]string, 0, len(set))
+	for t := range set {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	return tables
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -19,20 +20,17 @@ import (
 
 // 各频道对应独立表名，写入/查询均按 source 路由到对应表
 var (
-	allowedSources = []string{"github", "baidu", "gold", "ashare", "x", "hackernews"}
-	sourceToTable  = map[string]string{
-		"github": "news_github", "baidu": "news_baidu", "gold": "news_gold",
+	allowedSources = []string{"github", "github_developers", "baidu", "gold", "ashare", "x", "hackernews", "weibo", "zhihu", "bilibili", "douyin",
+		"v2ex", "lobsters", "reddit", "producthunt", "arxiv", "papers"}
+	sourceToTable = map[string]string{
+		"github": "news_github", "github_developers": "news_github_developers", "baidu": "news_baidu", "gold": "news_gold",
 		"ashare": "news_ashare", "x": "news_x", "hackernews": "news_hackernews",
+		"weibo": "news_weibo", "zhihu": "news_zhihu", "bilibili": "news_bilibili", "douyin": "news_douyin",
+		"v2ex": "news_v2ex", "lobsters": "news_lobsters", "reddit": "news_reddit", "producthunt": "news_producthunt",
+		"arxiv": "news_arxiv", "papers": "news_papers",
 	}
 )
 
-func newsTable(source string) string {
-	if t, ok := sourceToTable[source]; ok {
-		return t
-	}
-	return ""
-}
-
 func sortByPublishedAtDesc(list []News) {
 	sort.Slice(list, func(i, j int) bool { return list[i].PublishedAt.After(list[j].PublishedAt) })
 }
@@ -45,25 +43,24 @@ func sortByHotScoreDesc(list []News) {
 	})
 }
 
-// Channel 描述一个数据源，例如 weibo / zhihu / github
-type Channel struct {
-	ID      uint   `gorm:"primaryKey" json:"id"`
-	Code    string `gorm:"size:64;uniqueIndex" json:"code"` // 例如: github, zhihu
-	Name    string `gorm:"size:128" json:"name"`
-	BaseURL string `gorm:"size:256" json:"baseUrl"`
-	Status  string `gorm:"size:32;index" json:"status"` // active / disabled
-
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-}
-
 type News struct {
 	ID     string `gorm:"primaryKey;size:40" json:"id"`
 	Title  string `gorm:"size:512" json:"title"`
 	URL    string `gorm:"size:1024;uniqueIndex" json:"url"`
 	Source string `gorm:"size:64;index" json:"source"`
 	// 只保留一段介绍文案；长度控制在约 200 个字符（在 processor 中按 rune 截断）
-	Description   string            `gorm:"size:600" json:"description"` // 详细介绍，悬停显示
+	Description string `gorm:"size:600" json:"description"` // 详细介绍，悬停显示
+	// 翻译前的原文，为空表示 title / description 即原文
+	OriginalTitle       string `gorm:"size:512" json:"originalTitle,omitempty"`
+	OriginalDescription string `gorm:"size:600" json:"originalDescription,omitempty"`
+	// Translations 其它语言的译文：语言代码 → {"title": ..., "description": ...}
+	Translations datatypes.JSONMap `gorm:"type:jsonb" json:"translations,omitempty"`
+	// Summary 大模型生成的中文摘要，仅开启了 summarize 的渠道有值
+	Summary string `gorm:"size:1000" json:"summary,omitempty"`
+	// StoryID 跨数据源聚类得到的事件 ID，见 Story
+	StoryID string `gorm:"size:40;index" json:"storyId,omitempty"`
+	// Tags 主题标签，保存在 news_tags 表中，查询时填写
+	Tags          []string          `gorm:"-" json:"tags,omitempty"`
 	PublishedAt   time.Time         `gorm:"index" json:"publishedAt"`
 	PublishedDate string            `gorm:"size:10;index" json:"publishedDate"` // 日期 YYYY-MM-DD，用于按日期展示
 	HotScore      float64           `gorm:"index" json:"hotScore"`
@@ -76,6 +73,10 @@ type News struct {
 type Store struct {
 	DB    *gorm.DB
 	Redis *redis.Client
+
+	// sources 运行时注册的数据源 → 表名，见 RegisterSource
+	sourcesMu sync.RWMutex
+	sources   map[string]string
 }
 
 const (
@@ -100,22 +101,32 @@ func NewStore(dsn, redisAddr string) (*Store, error) {
 		return nil, fmt.Errorf("failed to connect after %d attempts: %w", dbConnectRetries, err)
 	}
 
-	if err := db.AutoMigrate(&Channel{}, &News{}, &WeatherCity{}, &WeatherCache{}, &AShareStock{}); err != nil {
+	if err := db.AutoMigrate(&Channel{}, &News{}, &WeatherCity{}, &WeatherCache{}, &AShareStock{}, &FetchRun{}, &Translation{}, &Summary{}, &LinkPreview{}, &Story{}, &StoryItem{}, &FilterRule{}, &NewsTag{}, &TagClassification{}, &DataMigration{}); err != nil {
+		return nil, err
+	}
+	// 早期版本的 story_items / news_tags 主键不含 source，不同数据源收录同一链接时会互相覆盖
+	if err := ensurePrimaryKey(db, "story_items", "news_id", "source"); err != nil {
+		return nil, err
+	}
+	if err := ensurePrimaryKey(db, "news_tags", "news_id", "source", "tag"); err != nil {
 		return nil, err
 	}
-	// 按频道分表：与 news 同结构，便于按 source 路由；并行建表
+	// 按频道分表：与 news 同结构，便于按 source 路由；另建订阅源共享表 news_feed。并行建表
 	var createErr error
 	var createErrMu sync.Mutex
 	var wg sync.WaitGroup
+	tables := make([]string, 0, len(allowedSources)+1)
 	for _, src := range allowedSources {
-		tbl := sourceToTable[src]
+		tables = append(tables, sourceToTable[src])
+	}
+	for _, tbl := range append(tables, sharedFeedTable) {
 		wg.Add(1)
 		go func(tbl string) {
 			defer wg.Done()
-			if err := db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (LIKE news INCLUDING ALL)", tbl)).Error; err != nil {
+			if err := ensureNewsTable(db, tbl); err != nil {
 				createErrMu.Lock()
 				if createErr == nil {
-					createErr = fmt.Errorf("create table %s: %w", tbl, err)
+					createErr = err
 				}
 				createErrMu.Unlock()
 			}
@@ -125,6 +136,12 @@ func NewStore(dsn, redisAddr string) (*Store, error) {
 	if createErr != nil {
 		return nil, createErr
 	}
+	if err := ensureSharedFeedKeys(db); err != nil {
+		return nil, err
+	}
+	if err := runDataMigrations(db); err != nil {
+		return nil, err
+	}
 
 	rdb := redis.NewClient(&redis.Options{
 		Addr: redisAddr,
@@ -139,6 +156,38 @@ func NewStore(dsn, redisAddr string) (*Store, error) {
 	return &Store{DB: db, Redis: rdb}, nil
 }
 
+// ensurePrimaryKey 已有表的主键列与 cols 不同时重建主键；AutoMigrate 不会修改已有表的主键
+func ensurePrimaryKey(db *gorm.DB, table string, cols ...string) error {
+	var current []string
+	if err := db.Raw(`SELECT a.attname FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = ?::regclass AND i.indisprimary`, table).Scan(&current).Error; err != nil {
+		return fmt.Errorf("read primary key of %s: %w", table, err)
+	}
+	sort.Strings(current)
+	want := append([]string(nil), cols...)
+	sort.Strings(want)
+	if slices.Equal(current, want) {
+		return nil
+	}
+	log.Printf("storage: rebuild primary key of %s as (%s)", table, strings.Join(cols, ", "))
+	return db.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s_pkey, ADD PRIMARY KEY (%s)", table, table, strings.Join(cols, ", "))).Error
+}
+
+// Close 关闭数据库连接池与 Redis 客户端，应在所有采集与请求结束后调用
+func (s *Store) Close() error {
+	var firstErr error
+	if sqlDB, err := s.DB.DB(); err != nil {
+		firstErr = err
+	} else if err := sqlDB.Close(); err != nil {
+		firstErr = err
+	}
+	if err := s.Redis.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
 // HasAshareDataForDate 判断指定日期（YYYY-MM-DD，东八区）是否已有任何 A 股数据，
 // 用于在采集层决定是否需要在收盘后额外补拉一次“当天快照”。
 func (s *Store) HasAshareDataForDate(date string) bool {
@@ -156,20 +205,6 @@ func (s *Store) HasAshareDataForDate(date string) bool {
 	return cnt > 0
 }
 
-// EnsureChannel 确保某个渠道存在，使用 FirstOrCreate 避免并发竞态
-func (s *Store) EnsureChannel(code, name, baseURL string) (*Channel, error) {
-	ch := &Channel{
-		Code:    code,
-		Name:    name,
-		BaseURL: baseURL,
-		Status:  "active",
-	}
-	if err := s.DB.Where("code = ?", code).FirstOrCreate(ch).Error; err != nil {
-		return nil, err
-	}
-	return ch, nil
-}
-
 // 东八区，用于日期展示与筛选
 var locEast8 *time.Location
 
@@ -202,51 +237,128 @@ func truncateRunesDB(s string, limit int) string {
 	return string(rs[:limit])
 }
 
-// SaveBatch 按频道保存到对应分表（news_github / news_baidu / news_gold / news_ashare / news_x），已存在的按 URL 更新
+// SaveBatch 按频道保存到对应分表（news_github / news_baidu / news_gold / news_ashare / news_x，订阅源见 RegisterSource），
+// 已存在的按 URL 更新；未知 source 直接忽略。Tags 不为 nil 的条目整体替换 news_tags 中的标签
 func (s *Store) SaveBatch(items []processor.ProcessedNews) error {
+	// 按数据源分组替换标签；同一批次通常只有一个数据源
+	taggedIDs := make(map[string][]string)
+	tags := make(map[string][]NewsTag)
 	for _, it := range items {
-		tbl := newsTable(it.Source)
-		if tbl == "" {
+		t, ok := s.target(it.Source)
+		if !ok {
 			continue
 		}
+		tbl := t.table
 		pubDate := it.PublishedAt.In(locEast8).Format("2006-01-02")
 		title := toValidUTF8(it.Title)
 		description := toValidUTF8(it.Description)
 		description = truncateRunesDB(description, 600)
+		origTitle := toValidUTF8(it.OriginalTitle)
+		origDescription := truncateRunesDB(toValidUTF8(it.OriginalDescription), 600)
+		translations := newsTranslations(it.Translations)
+		summary := truncateRunesDB(toValidUTF8(it.Summary), 1000)
 		n := &News{
-			ID:            it.ID,
-			Title:         title,
-			URL:           it.URL,
-			Source:        it.Source,
-			Description:   description,
-			PublishedAt:   it.PublishedAt,
-			PublishedDate: pubDate,
-			HotScore:      it.HotScore,
-			ExtraData:     datatypes.JSONMap(it.RawData),
+			ID:                  it.ID,
+			Title:               title,
+			URL:                 it.URL,
+			Source:              it.Source,
+			Description:         description,
+			OriginalTitle:       origTitle,
+			OriginalDescription: origDescription,
+			Translations:        translations,
+			Summary:             summary,
+			StoryID:             it.StoryID,
+			PublishedAt:         it.PublishedAt,
+			PublishedDate:       pubDate,
+			HotScore:            it.HotScore,
+			ExtraData:           datatypes.JSONMap(it.RawData),
 		}
 
-		if err := s.DB.Table(tbl).Where("url = ?", it.URL).FirstOrCreate(n).Error; err != nil {
+		// ID 由规范化后的链接生成，http / https 变体 ID 相同而 url 不同，因此按 ID 或 url 查找已有记录；
+		// 共享表中只查找本渠道的记录，其它渠道收录的同一链接各自保留
+		if err := t.scope(s.DB).Where("(id = ? OR url = ?)", it.ID, it.URL).FirstOrCreate(n).Error; err != nil {
 			return err
 		}
-		if err := s.DB.Table(tbl).Model(n).Updates(map[string]any{
-			"title":          title,
-			"description":    description,
-			"hot_score":      it.HotScore,
-			"published_at":   it.PublishedAt,
-			"published_date": pubDate,
-			"extra_data":     datatypes.JSONMap(it.RawData),
-		}).Error; err != nil {
+		updates := map[string]any{
+			"title":                title,
+			"description":          description,
+			"original_title":       origTitle,
+			"original_description": origDescription,
+			"hot_score":            it.HotScore,
+			"published_at":         it.PublishedAt,
+			"published_date":       pubDate,
+			"extra_data":           datatypes.JSONMap(it.RawData),
+		}
+		// 翻译失败或未配置额外语言时没有译文，保留已有译文
+		if len(it.Translations) > 0 {
+			updates["translations"] = translations
+		}
+		// 摘要受速率与单次条数限制，本次未生成时保留已有摘要
+		if summary != "" {
+			updates["summary"] = summary
+		}
+		if it.StoryID != "" {
+			updates["story_id"] = it.StoryID
+		}
+		if err := t.scope(s.DB).Model(n).Updates(updates).Error; err != nil {
 			return fmt.Errorf("update %s %s: %w", tbl, it.URL, err)
 		}
+		if it.Tags != nil {
+			// 已有记录时 n.ID 为库中的 ID，可能与 it.ID 不同（http / https 变体）
+			taggedIDs[it.Source] = append(taggedIDs[it.Source], n.ID)
+			for _, tag := range it.Tags {
+				tags[it.Source] = append(tags[it.Source], NewsTag{NewsID: n.ID, Source: it.Source, Tag: tag, PublishedDate: pubDate})
+			}
+		}
+	}
+	for source, ids := range taggedIDs {
+		if err := s.replaceNewsTags(source, ids, tags[source]); err != nil {
+			return fmt.Errorf("save news tags: %w", err)
+		}
 	}
 	return nil
 }
 
-// ListNews 按渠道、排序与可选日期返回新闻列表，并使用 Redis 做简单缓存
-// channel: 渠道 code，可为空
-// sort: latest(默认) / hot
-// date: 可选，格式 2006-01-02，指定则只返回该日期的数据
-func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News, error) {
+// NewsQuery 新闻列表查询条件
+type NewsQuery struct {
+	Channel string // 渠道 code，可为空
+	Sort    string // latest(默认) / hot
+	Limit   int
+	Date    string // 可选，格式 2006-01-02，指定则只返回该日期的数据
+	// Extra 按 ExtraData 字段过滤：字段值等于给定字符串，或字段为数组且包含该字符串，如 {"regions": "japan"}
+	Extra map[string]string
+	// Tag 可选，只返回带有该主题标签的条目
+	Tag string
+}
+
+// extraCacheKey 将 Extra 条件按 key 排序后拼接，保证缓存 key 稳定
+func (q NewsQuery) extraCacheKey() string {
+	if len(q.Extra) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(q.Extra))
+	for k := range q.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k + "=" + q.Extra[k] + ";")
+	}
+	return b.String()
+}
+
+// applyExtraFilters 为查询追加 ExtraData 过滤条件；jsonb 的 @> 对标量与数组均适用
+func applyExtraFilters(db *gorm.DB, extra map[string]string) *gorm.DB {
+	for k, v := range extra {
+		db = db.Where("extra_data -> ? @> to_jsonb(?::text)", k, v)
+	}
+	return db
+}
+
+// ListNews 按渠道、排序、可选日期与 ExtraData 条件返回新闻列表，并使用 Redis 做简单缓存
+func (s *Store) ListNews(q NewsQuery) ([]News, error) {
+	channel, sort, limit, date := q.Channel, q.Sort, q.Limit, q.Date
 	if limit <= 0 || limit > 1000 {
 		limit = 20
 	}
@@ -255,7 +367,7 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 	}
 
 	ctx := context.Background()
-	cacheKey := fmt.Sprintf("news:list:%s:%s:%d:%s", channel, sort, limit, date)
+	cacheKey := fmt.Sprintf("news:list:%s:%s:%d:%s:%s:%s", channel, sort, limit, date, q.extraCacheKey(), q.Tag)
 
 	// L2: Redis 缓存
 	if s.Redis != nil {
@@ -281,14 +393,14 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 			}
 		}
 		var goldList, ashareList []News
-		q := s.DB.Table("news_gold")
+		gq := applyTagFilter(s.DB.Table("news_gold"), q.Tag)
 		if dateCond {
-			q = q.Where(dateWhere, date, date)
+			gq = gq.Where(dateWhere, date, date)
 		} else {
-			q = q.Where("published_at >= ?", startOfDay)
+			gq = gq.Where("published_at >= ?", startOfDay)
 		}
-		q.Order("published_at ASC").Limit(500).Find(&goldList)
-		aq := s.DB.Table("news_ashare")
+		gq.Order("published_at ASC").Limit(500).Find(&goldList)
+		aq := applyTagFilter(s.DB.Table("news_ashare"), q.Tag)
 		if dateCond {
 			aq = aq.Where(dateWhere, date, date)
 		} else {
@@ -301,6 +413,7 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 		if len(list) > limit {
 			list = list[:limit]
 		}
+		s.attachTags(list)
 		// 回写缓存
 		if s.Redis != nil && len(list) > 0 {
 			if bs, err := json.Marshal(list); err == nil {
@@ -312,10 +425,9 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 
 	// 单频道：从对应分表查
 	if channel != "" {
-		tbl := newsTable(channel)
-		if tbl != "" {
+		if t, ok := s.target(channel); ok {
 			var list []News
-			db := s.DB.Table(tbl)
+			db := applyTagFilter(applyExtraFilters(t.scope(s.DB), q.Extra), q.Tag)
 			if dateCond {
 				db = db.Where(dateWhere, date, date)
 			}
@@ -328,6 +440,7 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 			if err := db.Limit(limit).Find(&list).Error; err != nil {
 				return nil, err
 			}
+			s.attachTags(list)
 			if s.Redis != nil && len(list) > 0 {
 				if bs, err := json.Marshal(list); err == nil {
 					_ = s.Redis.Set(ctx, cacheKey, bs, 5*time.Minute).Err()
@@ -339,9 +452,9 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 
 	// channel == ""：从所有分表合并后排序截断
 	var list []News
-	for _, tbl := range sourceToTable {
+	for _, tbl := range s.allTables() {
 		var part []News
-		db := s.DB.Table(tbl)
+		db := applyTagFilter(applyExtraFilters(s.DB.Table(tbl), q.Extra), q.Tag)
 		if dateCond {
 			db = db.Where(dateWhere, date, date)
 		}
@@ -357,6 +470,7 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 	if len(list) > limit {
 		list = list[:limit]
 	}
+	s.attachTags(list)
 
 	// 回写缓存（5 分钟，减轻每天首次打开时的 DB 压力）
 	const listCacheTTL = 5 * time.Minute
@@ -371,7 +485,7 @@ func (s *Store) ListNews(channel, sort string, limit int, date string) ([]News,
 
 // ListLatest 兼容旧接口
 func (s *Store) ListLatest(limit int) ([]News, error) {
-	return s.ListNews("", "latest", limit, "")
+	return s.ListNews(NewsQuery{Sort: "latest", Limit: limit})
 }
 
 // ListPublishedDates 返回有数据的日期列表（倒序）。兼容旧数据：published_date 为空时用 published_at 的日期；结果缓存 5 分钟
@@ -392,15 +506,15 @@ func (s *Store) ListPublishedDates(channel string, limit int) ([]string, error)
 
 	// 从分表取有数据的日期；channel 为空时合并所有表
 	baseSQL := `SELECT DISTINCT COALESCE(NULLIF(TRIM(published_date), ''), to_char(published_at AT TIME ZONE 'Asia/Shanghai', 'YYYY-MM-DD')) AS d FROM `
-	var tables []string
+	var tables []newsTarget
 	if channel == "" {
-		for _, t := range sourceToTable {
-			tables = append(tables, t)
+		for _, t := range s.allTables() {
+			tables = append(tables, newsTarget{table: t})
 		}
 	} else if channel == "gold" {
-		tables = []string{"news_gold", "news_ashare"}
-	} else if t := newsTable(channel); t != "" {
-		tables = []string{t}
+		tables = []newsTarget{{table: "news_gold"}, {table: "news_ashare"}}
+	} else if t, ok := s.target(channel); ok {
+		tables = []newsTarget{t}
 	}
 	if len(tables) == 0 {
 		if s.Redis != nil {
@@ -411,13 +525,18 @@ func (s *Store) ListPublishedDates(channel string, limit int) ([]string, error)
 	var dateSetMu sync.Mutex
 	dateSet := make(map[string]struct{})
 	var wg sync.WaitGroup
-	for _, tbl := range tables {
-		tbl := tbl
+	for _, t := range tables {
+		t := t
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			var rows []struct{ D string }
-			if err := s.DB.Raw(baseSQL+tbl+` ORDER BY d DESC LIMIT ?`, limit).Scan(&rows).Error; err != nil {
+			sql, args := baseSQL+t.table, []any{}
+			if t.shared {
+				sql += ` WHERE source = ?`
+				args = append(args, t.source)
+			}
+			if err := s.DB.Raw(sql+` ORDER BY d DESC LIMIT ?`, append(args, limit)...).Scan(&rows).Error; err != nil {
 				return
 			}
 			dateSetMu.Lock()
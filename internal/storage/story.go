@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Story 跨数据源的同一事件：标题相近的条目归入同一个 story，ID 取首个成员的新闻 ID，之后保持不变
+type Story struct {
+	ID    string `gorm:"primaryKey;size:40" json:"id"`
+	Title string `gorm:"size:512" json:"title"`
+	// SimHash 首个成员标题分词后的 64 位 SimHash，Tokens 为空格分隔的分词结果，用于相似度比较
+	SimHash   int64     `json:"-"`
+	Tokens    string    `gorm:"type:text" json:"-"`
+	ItemCount int       `json:"itemCount"`
+	FirstSeen time.Time `gorm:"index" json:"firstSeen"`
+	LastSeen  time.Time `gorm:"index" json:"lastSeen"`
+}
+
+// StoryItem story 的成员，冗余保存标题、链接与热度，列出 story 时无需跨分表查询。
+// 新闻 ID 由规范化后的链接生成，不同数据源收录同一链接时 ID 相同，因此按 (新闻 ID, 数据源) 区分成员
+type StoryItem struct {
+	NewsID      string    `gorm:"primaryKey;size:40" json:"id"`
+	Source      string    `gorm:"primaryKey;size:64" json:"source"`
+	StoryID     string    `gorm:"size:40;index" json:"storyId"`
+	Title       string    `gorm:"size:512" json:"title"`
+	URL         string    `gorm:"size:1024" json:"url"`
+	HotScore    float64   `json:"hotScore"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// StoryView 对外展示的 story：成员条目与合计热度
+type StoryView struct {
+	Story
+	// Heat 合计热度：各成员热度取 log1p 后求和，避免百万级的热搜热度淹没其它来源
+	Heat    float64     `json:"heat"`
+	Sources []string    `json:"sources"`
+	Items   []StoryItem `json:"items"`
+}
+
+// StoryQuery story 列表查询条件
+type StoryQuery struct {
+	Since      time.Time // 只返回 LastSeen 不早于该时间的 story
+	MinSources int       // 至少覆盖多少个数据源
+	Limit      int
+}
+
+// RecentStories 返回 LastSeen 不早于 since 的 story，用于聚类时比较
+func (s *Store) RecentStories(ctx context.Context, since time.Time) ([]Story, error) {
+	var list []Story
+	err := s.DB.WithContext(ctx).Where("last_seen >= ?", since).Order("last_seen DESC").Find(&list).Error
+	return list, err
+}
+
+// StoryMembersByNews 返回这些新闻 ID 在各数据源下已归类的成员（只填写 news_id、source 与 story_id）
+func (s *Store) StoryMembersByNews(ctx context.Context, newsIDs []string) ([]StoryItem, error) {
+	if len(newsIDs) == 0 {
+		return nil, nil
+	}
+	var rows []StoryItem
+	if err := s.DB.WithContext(ctx).Select("news_id", "source", "story_id").Where("news_id IN ?", newsIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SaveStories 写入本次新建或更新的 story 与成员：story 按 ID 覆盖，成员按 (新闻 ID, 数据源) 覆盖（更新标题与热度）
+func (s *Store) SaveStories(ctx context.Context, stories []Story, items []StoryItem) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(stories) > 0 {
+			for i := range stories {
+				stories[i].Title = toValidUTF8(stories[i].Title)
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"item_count", "last_seen"}),
+			}).Create(&stories).Error; err != nil {
+				return err
+			}
+		}
+		if len(items) > 0 {
+			for i := range items {
+				items[i].Title = toValidUTF8(items[i].Title)
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "news_id"}, {Name: "source"}},
+				DoUpdates: clause.AssignmentColumns([]string{"title", "url", "hot_score", "published_at", "updated_at"}),
+			}).Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListStories 按合计热度倒序返回 story 及其成员
+func (s *Store) ListStories(q StoryQuery) ([]StoryView, error) {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	var stories []Story
+	db := s.DB.Where("last_seen >= ?", q.Since)
+	if q.MinSources > 1 {
+		db = db.Where("item_count >= ?", q.MinSources)
+	}
+	if err := db.Order("last_seen DESC").Limit(500).Find(&stories).Error; err != nil {
+		return nil, err
+	}
+	if len(stories) == 0 {
+		return []StoryView{}, nil
+	}
+	ids := make([]string, len(stories))
+	for i, st := range stories {
+		ids[i] = st.ID
+	}
+	var items []StoryItem
+	if err := s.DB.Where("story_id IN ?", ids).Order("hot_score DESC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	members := make(map[string][]StoryItem, len(stories))
+	for _, it := range items {
+		members[it.StoryID] = append(members[it.StoryID], it)
+	}
+
+	views := make([]StoryView, 0, len(stories))
+	for _, st := range stories {
+		v := StoryView{Story: st, Items: members[st.ID], Sources: []string{}}
+		seen := make(map[string]bool)
+		for _, it := range v.Items {
+			v.Heat += math.Log1p(math.Max(it.HotScore, 0))
+			if !seen[it.Source] {
+				seen[it.Source] = true
+				v.Sources = append(v.Sources, it.Source)
+			}
+		}
+		if len(v.Items) == 0 || len(v.Sources) < q.MinSources {
+			continue
+		}
+		sort.Strings(v.Sources)
+		v.ItemCount = len(v.Items)
+		views = append(views, v)
+	}
+	sort.SliceStable(views, func(i, j int) bool { return views[i].Heat > views[j].Heat })
+	if len(views) > q.Limit {
+		views = views[:q.Limit]
+	}
+	return views, nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// summaryCacheTTL Redis 中摘要的过期时间；PostgreSQL 中长期保留
+const summaryCacheTTL = 7 * 24 * time.Hour
+
+// Summary 摘要缓存：key 为条目 ID（URL 的哈希），同一链接只请求一次大模型
+type Summary struct {
+	ID        string    `gorm:"primaryKey;size:40" json:"id"`
+	Model     string    `gorm:"size:64" json:"model"`
+	Text      string    `gorm:"type:text" json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func summaryRedisKey(id string) string {
+	return "summary:" + id
+}
+
+// GetSummary 先查 Redis，未命中再查 PostgreSQL 并回填 Redis
+func (s *Store) GetSummary(ctx context.Context, id string) (string, bool) {
+	if s.Redis != nil {
+		if v, err := s.Redis.Get(ctx, summaryRedisKey(id)).Result(); err == nil {
+			return v, true
+		}
+	}
+	var sm Summary
+	silent := s.DB.Session(&gorm.Session{Logger: s.DB.Logger.LogMode(logger.Silent)})
+	if err := silent.WithContext(ctx).Where("id = ?", id).First(&sm).Error; err != nil {
+		return "", false
+	}
+	if s.Redis != nil {
+		_ = s.Redis.Set(ctx, summaryRedisKey(id), sm.Text, summaryCacheTTL).Err()
+	}
+	return sm.Text, true
+}
+
+// SaveSummary 写入摘要缓存（PostgreSQL + Redis），写入失败只影响缓存命中，不返回错误
+func (s *Store) SaveSummary(ctx context.Context, id, model, text string) {
+	sm := Summary{ID: id, Model: model, Text: toValidUTF8(text), CreatedAt: time.Now()}
+	_ = s.DB.WithContext(ctx).Save(&sm).Error
+	if s.Redis != nil {
+		_ = s.Redis.Set(ctx, summaryRedisKey(id), sm.Text, summaryCacheTTL).Err()
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// translationCacheTTL Redis 中翻译结果的过期时间；PostgreSQL 中长期保留
+const translationCacheTTL = 7 * 24 * time.Hour
+
+// Translation 翻译缓存：key 为目标语言与原文的 SHA-256，相同文本不再重复请求翻译接口
+type Translation struct {
+	Hash      string    `gorm:"primaryKey;size:64" json:"hash"`
+	Provider  string    `gorm:"size:32" json:"provider"`
+	Text      string    `gorm:"type:text" json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func translationRedisKey(hash string) string {
+	return "translate:" + hash
+}
+
+// GetTranslation 先查 Redis，未命中再查 PostgreSQL 并回填 Redis
+func (s *Store) GetTranslation(ctx context.Context, hash string) (string, bool) {
+	if s.Redis != nil {
+		if v, err := s.Redis.Get(ctx, translationRedisKey(hash)).Result(); err == nil {
+			return v, true
+		}
+	}
+	var t Translation
+	silent := s.DB.Session(&gorm.Session{Logger: s.DB.Logger.LogMode(logger.Silent)})
+	if err := silent.WithContext(ctx).Where("hash = ?", hash).First(&t).Error; err != nil {
+		return "", false
+	}
+	if s.Redis != nil {
+		_ = s.Redis.Set(ctx, translationRedisKey(hash), t.Text, translationCacheTTL).Err()
+	}
+	return t.Text, true
+}
+
+// SaveTranslation 写入翻译缓存（PostgreSQL + Redis），写入失败只影响缓存命中，不返回错误
+func (s *Store) SaveTranslation(ctx context.Context, hash, provider, text string) {
+	t := Translation{Hash: hash, Provider: provider, Text: text, CreatedAt: time.Now()}
+	_ = s.DB.WithContext(ctx).Save(&t).Error
+	if s.Redis != nil {
+		_ = s.Redis.Set(ctx, translationRedisKey(hash), text, translationCacheTTL).Err()
+	}
+}
+
+// LangOriginal 渲染原文的 lang 取值
+const LangOriginal = "original"
+
+// newsTranslations 将各语言的译文转为 jsonb 字段，没有译文时返回 nil
+func newsTranslations(tr map[string]map[string]string) datatypes.JSONMap {
+	if len(tr) == 0 {
+		return nil
+	}
+	m := make(datatypes.JSONMap, len(tr))
+	for lang, fields := range tr {
+		v := make(map[string]any, len(fields))
+		for k, text := range fields {
+			v[k] = toValidUTF8(text)
+		}
+		m[lang] = v
+	}
+	return m
+}
+
+// Localize 将 Title / Description 替换为 lang 对应的文本：空或 zh 保持默认（中文），original 为原文，
+// 其它语言取 Translations 中的译文，缺少该语言的译文时回退为原文
+func (n *News) Localize(lang string) {
+	if lang == "" || lang == "zh" {
+		return
+	}
+	if lang != LangOriginal {
+		if fields, ok := n.Translations[lang].(map[string]any); ok {
+			if t, _ := fields["title"].(string); t != "" {
+				n.Title = t
+			}
+			if d, _ := fields["description"].(string); d != "" {
+				n.Description = d
+			}
+			return
+		}
+	}
+	if n.OriginalTitle != "" {
+		n.Title = n.OriginalTitle
+	}
+	if n.OriginalDescription != "" {
+		n.Description = n.OriginalDescription
+	}
+}
@@ -0,0 +1,236 @@
+// Package story 把不同数据源中标题相近的条目聚合为同一事件（story）：
+// 标题按中日韩 bigram / 英文单词切分后，用 SimHash 与词集合相似度比较，成员关系持久化，跨天保持同一 story ID
+package story
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+const (
+	// DefaultWindow 只与最近该时间内出现过的 story 比较
+	DefaultWindow = 72 * time.Hour
+	// reloadInterval 定期从数据库重新加载 story 索引，合并其它副本新建的 story
+	reloadInterval = 10 * time.Minute
+)
+
+// DefaultSkipSources 默认不参与聚类的数据源：金融快照的标题每次几乎相同，聚合没有意义
+var DefaultSkipSources = []string{"gold", "ashare"}
+
+// Store story 的持久化，由 storage.Store 实现
+type Store interface {
+	RecentStories(ctx context.Context, since time.Time) ([]storage.Story, error)
+	StoryMembersByNews(ctx context.Context, newsIDs []string) ([]storage.StoryItem, error)
+	SaveStories(ctx context.Context, stories []storage.Story, items []storage.StoryItem) error
+}
+
+// Config 聚类配置
+type Config struct {
+	// Window 与多久内出现过的 story 比较，<=0 时为 DefaultWindow
+	Window time.Duration
+	// SkipSources 不参与聚类的数据源，为 nil 时使用 DefaultSkipSources
+	SkipSources []string
+}
+
+// entry 内存索引中的 story 及其分词结果
+type entry struct {
+	story  storage.Story
+	tokens []string
+	hash   uint64
+}
+
+// Clusterer 维护最近 story 的内存索引（词 → story 的倒排表），为条目分配 story ID
+type Clusterer struct {
+	cfg   Config
+	store Store
+	skip  map[string]bool
+
+	mu       sync.Mutex
+	loadedAt time.Time
+	stories  map[string]*entry
+	postings map[string][]string
+}
+
+// New 创建聚类器，索引在首次调用 ClusterItems 时加载
+func New(cfg Config, store Store) *Clusterer {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.SkipSources == nil {
+		cfg.SkipSources = DefaultSkipSources
+	}
+	skip := make(map[string]bool, len(cfg.SkipSources))
+	for _, s := range cfg.SkipSources {
+		skip[s] = true
+	}
+	return &Clusterer{cfg: cfg, store: store, skip: skip}
+}
+
+// ClusterItems 为条目填写 StoryID：已归类过的条目沿用原 story；其它数据源已收录同一链接时加入其 story；否则与窗口内的 story 比较，
+// 取相似度最高的一个，没有相近的则以该条目新建 story。失败只记日志，条目的 StoryID 保持为空。返回填写了 StoryID 的条目数
+func (c *Clusterer) ClusterItems(ctx context.Context, items []processor.ProcessedNews) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if err := c.ensureLoaded(ctx, now); err != nil {
+		log.Printf("story: load index: %v", err)
+		return 0
+	}
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		if !c.skip[it.Source] {
+			ids = append(ids, it.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0
+	}
+	existing, err := c.store.StoryMembersByNews(ctx, ids)
+	if err != nil {
+		log.Printf("story: load assignments: %v", err)
+		return 0
+	}
+	// assigned 按 (数据源, 新闻 ID) 记录已归类的成员；sameURL 记录同一链接在任一数据源下所属的 story
+	assigned := make(map[memberKey]string, len(existing))
+	sameURL := make(map[string]string, len(existing))
+	for _, m := range existing {
+		assigned[memberKey{m.Source, m.NewsID}] = m.StoryID
+		sameURL[m.NewsID] = m.StoryID
+	}
+
+	changed := make(map[string]bool)
+	storyIDs := make([]string, len(items))
+	var members []storage.StoryItem
+	for i, it := range items {
+		if c.skip[it.Source] {
+			continue
+		}
+		sid, ok := assigned[memberKey{it.Source, it.ID}]
+		if !ok {
+			// 其它数据源已收录同一链接时直接加入其 story，不再比较标题
+			sid = sameURL[it.ID]
+			if sid == "" {
+				sid = c.assign(it, now)
+			}
+			if sid == "" {
+				continue
+			}
+			if e := c.stories[sid]; e != nil {
+				e.story.ItemCount++
+			}
+		}
+		// 超出窗口的旧 story 不在索引中，只更新成员
+		if e := c.stories[sid]; e != nil {
+			e.story.LastSeen = now
+			changed[sid] = true
+		}
+		storyIDs[i] = sid
+		members = append(members, storage.StoryItem{
+			NewsID: it.ID, StoryID: sid, Source: it.Source, Title: it.Title, URL: it.URL,
+			HotScore: it.HotScore, PublishedAt: it.PublishedAt, UpdatedAt: now,
+		})
+	}
+
+	stories := make([]storage.Story, 0, len(changed))
+	for id := range changed {
+		stories = append(stories, c.stories[id].story)
+	}
+	if err := c.store.SaveStories(ctx, stories, members); err != nil {
+		log.Printf("story: save: %v", err)
+		// 内存索引可能已与数据库不一致，下次重新加载
+		c.loadedAt = time.Time{}
+		return 0
+	}
+	assignedCount := 0
+	for i, sid := range storyIDs {
+		if sid != "" {
+			items[i].StoryID = sid
+			assignedCount++
+		}
+	}
+	return assignedCount
+}
+
+// memberKey story 成员的唯一标识：不同数据源收录同一链接时新闻 ID 相同
+type memberKey struct {
+	source string
+	newsID string
+}
+
+// assign 为未归类的条目找到标题相近的 story，没有时以该条目新建 story；标题过短时返回空串
+func (c *Clusterer) assign(it processor.ProcessedNews, now time.Time) string {
+	tokens := Tokenize(it.Title)
+	if len(tokens) < minTokens {
+		return ""
+	}
+	hash := SimHash(tokens)
+	if sid := c.match(tokens, hash, now); sid != "" {
+		return sid
+	}
+	if _, ok := c.stories[it.ID]; !ok {
+		c.add(&entry{
+			story: storage.Story{
+				ID: it.ID, Title: it.Title, SimHash: int64(hash), Tokens: strings.Join(tokens, " "),
+				FirstSeen: now,
+			},
+			tokens: tokens,
+			hash:   hash,
+		})
+	}
+	return it.ID
+}
+
+// match 返回与标题最相近且判定为同一事件的 story ID，没有时返回空串
+func (c *Clusterer) match(tokens []string, hash uint64, now time.Time) string {
+	since := now.Add(-c.cfg.Window)
+	best, bestScore := "", 0.0
+	checked := make(map[string]bool)
+	for _, tok := range tokens {
+		for _, id := range c.postings[tok] {
+			if checked[id] {
+				continue
+			}
+			checked[id] = true
+			e := c.stories[id]
+			if e == nil || e.story.LastSeen.Before(since) {
+				continue
+			}
+			if score, ok := similarity(tokens, e.tokens, hash, e.hash); ok && score > bestScore {
+				best, bestScore = id, score
+			}
+		}
+	}
+	return best
+}
+
+// ensureLoaded 首次调用或距上次加载超过 reloadInterval 时从数据库重建索引
+func (c *Clusterer) ensureLoaded(ctx context.Context, now time.Time) error {
+	if c.stories != nil && now.Sub(c.loadedAt) < reloadInterval {
+		return nil
+	}
+	list, err := c.store.RecentStories(ctx, now.Add(-c.cfg.Window))
+	if err != nil {
+		return err
+	}
+	c.stories = make(map[string]*entry, len(list))
+	c.postings = make(map[string][]string)
+	for _, st := range list {
+		c.add(&entry{story: st, tokens: strings.Fields(st.Tokens), hash: uint64(st.SimHash)})
+	}
+	c.loadedAt = now
+	return nil
+}
+
+func (c *Clusterer) add(e *entry) {
+	c.stories[e.story.ID] = e
+	for _, tok := range e.tokens {
+		c.postings[tok] = append(c.postings[tok], e.story.ID)
+	}
+}
@@ -0,0 +1,185 @@
+package story
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+	"github.com/LJTian/TrendingHub/internal/storage"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"#神舟二十号发射成功#", []string{"神舟", "舟二", "二十", "十号", "号发", "发射", "射成", "成功"}},
+		{"Show HN: The Go 1.24 Release", []string{"go", "1", "24", "release"}},
+		// 全角字母与数字按半角处理，中英混排分别切分
+		{"ＯｐｅｎＡＩ发布GPT５", []string{"openai", "发布", "gpt5"}},
+		{"雪 Snow", []string{"雪", "snow"}},
+		{"a a a", nil},
+	}
+	for _, c := range cases {
+		if got := Tokenize(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Tokenize(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"神舟二十号发射成功", "神舟二十号成功发射", true},
+		{"#神舟二十号发射成功#", "神舟二十号载人飞船发射成功 航天员状态良好", true},
+		{"Go 1.24 is released", "Go 1.24 released", true},
+		{"神舟二十号发射成功", "天舟九号发射成功", false},
+		{"Go 1.24 is released", "Rust 1.85 is released", false},
+		{"苹果", "苹果发布会", false},
+	}
+	for _, c := range cases {
+		a, b := Tokenize(c.a), Tokenize(c.b)
+		if _, got := similarity(a, b, SimHash(a), SimHash(b)); got != c.want {
+			t.Errorf("similarity(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestSimHashHamming(t *testing.T) {
+	a := SimHash(Tokenize("OpenAI releases a new reasoning model for developers"))
+	b := SimHash(Tokenize("OpenAI releases new reasoning model for developers today"))
+	c := SimHash(Tokenize("台风登陆广东 多地停课停运"))
+	if d := hamming(a, b); d >= hamming(a, c) {
+		t.Errorf("similar titles should be closer: %d vs %d", d, hamming(a, c))
+	}
+}
+
+type memoryStore struct {
+	stories map[string]storage.Story
+	items   map[memberKey]storage.StoryItem
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{stories: map[string]storage.Story{}, items: map[memberKey]storage.StoryItem{}}
+}
+
+func (m *memoryStore) RecentStories(_ context.Context, since time.Time) ([]storage.Story, error) {
+	var out []storage.Story
+	for _, st := range m.stories {
+		if !st.LastSeen.Before(since) {
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryStore) StoryMembersByNews(_ context.Context, ids []string) ([]storage.StoryItem, error) {
+	var out []storage.StoryItem
+	for _, it := range m.items {
+		if slices.Contains(ids, it.NewsID) {
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryStore) SaveStories(_ context.Context, stories []storage.Story, items []storage.StoryItem) error {
+	for _, st := range stories {
+		m.stories[st.ID] = st
+	}
+	for _, it := range items {
+		m.items[memberKey{it.Source, it.NewsID}] = it
+	}
+	return nil
+}
+
+func TestClusterItems(t *testing.T) {
+	store := newMemoryStore()
+	c := New(Config{}, store)
+	ctx := context.Background()
+
+	batch := []processor.ProcessedNews{
+		{ID: "b1", Source: "baidu", Title: "神舟二十号发射成功", HotScore: 4900000},
+		{ID: "b2", Source: "baidu", Title: "天舟九号发射成功"},
+		{ID: "g1", Source: "gold", Title: "国际金价"},
+	}
+	c.ClusterItems(ctx, batch)
+	if batch[0].StoryID != "b1" || batch[1].StoryID != "b2" || batch[2].StoryID != "" {
+		t.Fatalf("story ids = %q %q %q", batch[0].StoryID, batch[1].StoryID, batch[2].StoryID)
+	}
+
+	// 其它数据源的相近标题归入已有 story
+	batch = []processor.ProcessedNews{
+		{ID: "w1", Source: "weibo", Title: "#神舟二十号成功发射#"},
+		{ID: "z1", Source: "zhihu", Title: "如何看待神舟二十号载人飞船发射成功？"},
+	}
+	c.ClusterItems(ctx, batch)
+	if batch[0].StoryID != "b1" || batch[1].StoryID != "b1" {
+		t.Fatalf("cross-source story ids = %q %q", batch[0].StoryID, batch[1].StoryID)
+	}
+	if got := store.stories["b1"].ItemCount; got != 3 {
+		t.Errorf("b1 item count = %d, want 3", got)
+	}
+
+	// 重新加载索引后，已归类的条目即使标题改变也沿用原 story，新条目仍能匹配
+	c = New(Config{}, store)
+	batch = []processor.ProcessedNews{
+		{ID: "b1", Source: "baidu", Title: "航天员进驻空间站"},
+		{ID: "d1", Source: "douyin", Title: "神舟二十号发射成功瞬间"},
+	}
+	c.ClusterItems(ctx, batch)
+	if batch[0].StoryID != "b1" || batch[1].StoryID != "b1" {
+		t.Fatalf("story ids after reload = %q %q", batch[0].StoryID, batch[1].StoryID)
+	}
+	if got := store.items[memberKey{"baidu", "b1"}].Title; got != "航天员进驻空间站" {
+		t.Errorf("member title should be refreshed, got %q", got)
+	}
+	if got := store.stories["b1"].ItemCount; got != 4 {
+		t.Errorf("b1 item count = %d, want 4", got)
+	}
+}
+
+func TestClusterItemsSharedURL(t *testing.T) {
+	store := newMemoryStore()
+	c := New(Config{}, store)
+	ctx := context.Background()
+
+	// Hacker News 与 Lobsters 收录同一篇文章：链接相同因此新闻 ID 相同，标题不同
+	hn := []processor.ProcessedNews{{ID: "same", Source: "hackernews", Title: "Postgres 18 released with async I/O", HotScore: 300}}
+	c.ClusterItems(ctx, hn)
+	lobsters := []processor.ProcessedNews{{ID: "same", Source: "lobsters", Title: "PostgreSQL 18: what's new", HotScore: 40}}
+	c.ClusterItems(ctx, lobsters)
+	if hn[0].StoryID != "same" || lobsters[0].StoryID != "same" {
+		t.Fatalf("story ids = %q %q", hn[0].StoryID, lobsters[0].StoryID)
+	}
+	if len(store.items) != 2 || store.items[memberKey{"hackernews", "same"}].Source != "hackernews" || store.items[memberKey{"lobsters", "same"}].HotScore != 40 {
+		t.Fatalf("members should be kept per source: %+v", store.items)
+	}
+	if got := store.stories["same"].ItemCount; got != 2 {
+		t.Errorf("item count = %d, want 2", got)
+	}
+
+	// 再次采集时各自沿用成员记录，计数不变
+	c.ClusterItems(ctx, hn)
+	c.ClusterItems(ctx, lobsters)
+	if got := store.stories["same"].ItemCount; got != 2 || len(store.items) != 2 {
+		t.Errorf("after refetch: item count = %d, members = %d", got, len(store.items))
+	}
+}
+
+func TestClusterItemsWindow(t *testing.T) {
+	store := newMemoryStore()
+	old := time.Now().Add(-100 * time.Hour)
+	store.stories["old"] = storage.Story{ID: "old", Title: "神舟二十号发射成功", Tokens: "神舟 舟二 二十 十号 号发 发射 射成 成功", FirstSeen: old, LastSeen: old}
+
+	batch := []processor.ProcessedNews{{ID: "n1", Source: "baidu", Title: "神舟二十号发射成功"}}
+	New(Config{}, store).ClusterItems(context.Background(), batch)
+	if batch[0].StoryID != "n1" {
+		t.Errorf("story outside the window should not be matched, got %q", batch[0].StoryID)
+	}
+}
@@ -0,0 +1,165 @@
+package story
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// stopwords 英文标题中的虚词与热榜常见前缀，不参与比较
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "to": true, "in": true, "on": true, "for": true,
+	"and": true, "or": true, "is": true, "are": true, "was": true, "be": true, "with": true, "by": true,
+	"at": true, "as": true, "from": true, "it": true, "its": true, "this": true, "that": true,
+	"how": true, "why": true, "what": true, "new": true, "via": true, "vs": true,
+	"show": true, "ask": true, "hn": true, "tell": true, "launch": true,
+}
+
+// isCJK 中日韩文字：没有空格分词，按相邻两字切分
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// Tokenize 把标题切分为去重后的词：先做 NFKC 归一（全角转半角）并转小写；
+// 拉丁字母与数字按词切分并去掉虚词与单个字母，中日韩文字按相邻两字（bigram）切分，单独出现的一个字保留为一个词。
+// 标点、空白与 # 等符号只起分隔作用
+func Tokenize(title string) []string {
+	title = strings.ToLower(norm.NFKC.String(title))
+	var out []string
+	seen := make(map[string]bool)
+	add := func(tok string) {
+		if tok != "" && !seen[tok] {
+			seen[tok] = true
+			out = append(out, tok)
+		}
+	}
+	flushWord := func(w []rune) {
+		if len(w) == 1 && !unicode.IsDigit(w[0]) {
+			return
+		}
+		if s := string(w); !stopwords[s] {
+			add(s)
+		}
+	}
+	flushCJK := func(run []rune) {
+		if len(run) == 1 {
+			add(string(run))
+			return
+		}
+		for i := 0; i+1 < len(run); i++ {
+			add(string(run[i : i+2]))
+		}
+	}
+
+	var word, run []rune
+	for _, r := range title {
+		switch {
+		case isCJK(r):
+			if len(word) > 0 {
+				flushWord(word)
+				word = word[:0]
+			}
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(run) > 0 {
+				flushCJK(run)
+				run = run[:0]
+			}
+			word = append(word, r)
+		default:
+			if len(word) > 0 {
+				flushWord(word)
+				word = word[:0]
+			}
+			if len(run) > 0 {
+				flushCJK(run)
+				run = run[:0]
+			}
+		}
+	}
+	if len(word) > 0 {
+		flushWord(word)
+	}
+	if len(run) > 0 {
+		flushCJK(run)
+	}
+	return out
+}
+
+// SimHash 计算词集合的 64 位 SimHash（各词权重相同），相近的标题海明距离小
+func SimHash(tokens []string) uint64 {
+	var v [64]int
+	for _, tok := range tokens {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(tok))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				v[i]++
+			} else {
+				v[i]--
+			}
+		}
+	}
+	var out uint64
+	for i := 0; i < 64; i++ {
+		if v[i] > 0 {
+			out |= 1 << uint(i)
+		}
+	}
+	return out
+}
+
+// hamming 两个 SimHash 的海明距离
+func hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// 判定两个标题属于同一事件的阈值
+const (
+	// minTokens 词数少于该值的标题信息量太少，不参与聚类
+	minTokens = 2
+	// maxHamming SimHash 海明距离不超过该值视为相同（双方词数均不少于 simhashMinTokens 时才使用）
+	maxHamming       = 3
+	simhashMinTokens = 4
+	// minJaccard 词集合的 Jaccard 相似度阈值
+	minJaccard = 0.5
+	// minOverlap 较短标题的词有该比例出现在较长标题中时视为相同（如热搜词与完整新闻标题），较短标题至少 overlapMinTokens 个词
+	minOverlap       = 0.8
+	overlapMinTokens = 4
+)
+
+// similarity 返回两个标题的 Jaccard 相似度，以及是否判定为同一事件
+func similarity(a, b []string, ha, hb uint64) (float64, bool) {
+	if len(a) < minTokens || len(b) < minTokens {
+		return 0, false
+	}
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[t] = true
+	}
+	inter := 0
+	for _, t := range b {
+		if set[t] {
+			inter++
+		}
+	}
+	if inter == 0 {
+		return 0, false
+	}
+	jaccard := float64(inter) / float64(len(a)+len(b)-inter)
+	small := min(len(a), len(b))
+	switch {
+	case jaccard >= minJaccard:
+		return jaccard, true
+	case small >= simhashMinTokens && hamming(ha, hb) <= maxHamming:
+		return jaccard, true
+	case small >= overlapMinTokens && float64(inter)/float64(small) >= minOverlap:
+		return jaccard, true
+	}
+	return jaccard, false
+}
@@ -0,0 +1,289 @@
+// Package summarizer 调用 OpenAI 兼容的 Chat Completions 接口，为条目生成简短的中文摘要
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/enricher"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+const (
+	defaultRatePerMinute = 10
+	defaultMaxPerRun     = 10
+	requestTimeout       = 60 * time.Second
+	maxResponseBytes     = 1 << 20
+	// maxInputRunes 送入大模型的正文上限，控制 token 消耗
+	maxInputRunes = 6000
+	// minArticleRunes 正文少于该字数时视为提取失败，改用条目自带的介绍
+	minArticleRunes = 200
+	// minInputRunes 可用文本少于该字数时不生成摘要，避免大模型复述标题
+	minInputRunes = 60
+	// MaxSummaryRunes 摘要的最大长度
+	MaxSummaryRunes = 300
+)
+
+const systemPrompt = "你是资讯编辑。请用简体中文为用户给出的文章写 2~3 句摘要，总字数不超过 120 字，" +
+	"概括文章的核心事实与结论，不要添加原文没有的信息。只输出摘要本身，不要标题、引号或前缀。"
+
+// Config 摘要服务配置
+type Config struct {
+	// BaseURL OpenAI 兼容接口的 base URL（如 https://api.openai.com/v1），请求 <base>/chat/completions
+	BaseURL string
+	APIKey  string
+	Model   string
+	// RatePerMinute 每分钟最多请求大模型的次数（所有渠道共享），<=0 时为 10
+	RatePerMinute int
+	// MaxPerRun 单次采集最多处理的未缓存条目数（每条至多抓取一次原文、请求一次大模型），其余条目留待下次采集，<=0 时为 10
+	MaxPerRun int
+	// MaxArticleBytes 抓取原文页面的读取上限，<=0 时使用 collector.ArticleMaxBodyBytes
+	MaxArticleBytes int64
+	// Client 可选，注入自定义 HTTP 客户端（抓取原文与请求大模型共用）；为空时抓取原文只允许连接公网地址，
+	// 请求大模型不受限制（接口可以部署在内网）
+	Client *http.Client
+}
+
+// Cache 摘要缓存，key 为条目 ID；text 为空表示该条目没有可用于摘要的文本，之后不再尝试
+type Cache interface {
+	GetSummary(ctx context.Context, id string) (string, bool)
+	SaveSummary(ctx context.Context, id, model, text string)
+}
+
+// Summarizer 为条目生成摘要：命中缓存直接使用，否则抓取原文、请求大模型并写入缓存
+type Summarizer struct {
+	cfg     Config
+	cache   Cache
+	limiter *limiter
+	robots  *collector.RobotsChecker
+	// articleClient 抓取原文使用的客户端，为 nil 时使用 collector 只允许公网地址的默认客户端
+	articleClient *http.Client
+}
+
+// errRobotsDisallowed robots.txt 禁止抓取原文，改用条目自带的介绍
+var errRobotsDisallowed = errors.New("disallowed by robots.txt")
+
+// New 创建摘要服务；BaseURL 或 Model 为空时返回 nil，表示未开启
+func New(cfg Config, cache Cache) *Summarizer {
+	cfg.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if cfg.BaseURL == "" || cfg.Model == "" {
+		return nil
+	}
+	if cfg.RatePerMinute <= 0 {
+		cfg.RatePerMinute = defaultRatePerMinute
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = defaultMaxPerRun
+	}
+	articleClient := cfg.Client
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{}
+	}
+	return &Summarizer{
+		cfg:           cfg,
+		cache:         cache,
+		limiter:       newLimiter(time.Minute / time.Duration(cfg.RatePerMinute)),
+		robots:        &collector.RobotsChecker{Client: articleClient},
+		articleClient: articleClient,
+	}
+}
+
+// SummarizeItems 依次为条目填写 Summary。单条失败只记日志；ctx 结束或达到 MaxPerRun 后剩余条目保持为空，
+// 入库时不会覆盖已有摘要。可用文本过短且不是瞬时错误造成的（robots.txt 禁止、原文过短、4xx 等）条目
+// 缓存为空摘要，之后不再抓取原文。返回填写了摘要的条目数（含命中缓存的）
+func (s *Summarizer) SummarizeItems(ctx context.Context, items []processor.ProcessedNews) int {
+	attempted, filled := 0, 0
+	for i := range items {
+		if ctx.Err() != nil {
+			return filled
+		}
+		it := &items[i]
+		if s.cache != nil {
+			if text, ok := s.cache.GetSummary(ctx, it.ID); ok {
+				if text != "" {
+					it.Summary = text
+					filled++
+				}
+				continue
+			}
+		}
+		// 每条未缓存的条目都可能抓取一次原文，无论最终是否生成摘要都计入上限
+		if attempted >= s.cfg.MaxPerRun {
+			continue
+		}
+		attempted++
+		input, final := s.articleInput(ctx, it)
+		if utf8.RuneCountInString(input) < minInputRunes {
+			if final && s.cache != nil && ctx.Err() == nil {
+				s.cache.SaveSummary(ctx, it.ID, s.cfg.Model, "")
+			}
+			continue
+		}
+		if err := s.limiter.wait(ctx); err != nil {
+			return filled
+		}
+		text, err := s.complete(ctx, input)
+		if err != nil {
+			log.Printf("summarize %s: %v", it.URL, err)
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		it.Summary = text
+		filled++
+		if s.cache != nil {
+			s.cache.SaveSummary(ctx, it.ID, s.cfg.Model, text)
+		}
+	}
+	return filled
+}
+
+// articleInput 组装送入大模型的文本：标题 + 原文正文（优先使用链接预览中的正文）；robots.txt 禁止、原文抓取失败或过短时使用条目自带的介绍。
+// final 为 false 表示 robots.txt 或原文因瞬时错误未能获取，下次采集可能得到不同的结果
+func (s *Summarizer) articleInput(ctx context.Context, it *processor.ProcessedNews) (input string, final bool) {
+	title := collector.FirstNonEmpty(it.OriginalTitle, it.Title)
+	// 链接预览阶段已提取过正文时直接使用，不再重复抓取
+	if preview, ok := it.RawData[enricher.PreviewKey].(map[string]any); ok {
+		if text, _ := preview["text"].(string); utf8.RuneCountInString(text) >= minArticleRunes {
+			return processor.TruncateRunes("标题："+title+"\n\n正文：\n"+text, maxInputRunes), true
+		}
+	}
+	body := ""
+	var a collector.Article
+	err := errRobotsDisallowed
+	// robots.txt 暂时无法获取时同样不抓取原文，改用介绍
+	allowed, robotsErr := s.robots.Allowed(ctx, it.URL)
+	final = robotsErr == nil
+	if allowed {
+		a, err = collector.FetchArticle(ctx, s.articleClient, it.URL, s.cfg.MaxArticleBytes)
+		if err != nil && !collector.IsPermanent(err) {
+			final = false
+		}
+	}
+	if err == nil && utf8.RuneCountInString(a.Text) >= minArticleRunes {
+		body = a.Text
+	} else {
+		if err != nil && !errors.Is(err, collector.ErrNotHTML) {
+			log.Printf("summarize %s: fetch article: %v", it.URL, err)
+		}
+		body = collector.FirstNonEmpty(it.OriginalDescription, it.Description)
+		if body == it.Title || body == title {
+			body = ""
+		}
+	}
+	if body == "" {
+		return "", final
+	}
+	return processor.TruncateRunes("标题："+title+"\n\n正文：\n"+body, maxInputRunes), final
+}
+
+// complete 请求 Chat Completions 接口，返回清理后的摘要文本
+func (s *Summarizer) complete(ctx context.Context, input string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]any{
+		"model":       s.cfg.Model,
+		"temperature": 0.2,
+		"messages": []map[string]string{
+			{"role": "system", "content": systemPrompt},
+			{"role": "user", "content": input},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("chat completions: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("chat completions: decode: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", nil
+	}
+	return cleanSummary(out.Choices[0].Message.Content), nil
+}
+
+// cleanSummary 去掉模型常见的 "摘要：" 前缀、包裹引号与多余空白，并限制长度
+func cleanSummary(s string) string {
+	s = strings.TrimSpace(s)
+	for _, prefix := range []string{"摘要：", "摘要:", "总结：", "总结:"} {
+		s = strings.TrimSpace(strings.TrimPrefix(s, prefix))
+	}
+	s = strings.Trim(s, "\"“”「」")
+	s = strings.Join(strings.Fields(s), " ")
+	return processor.TruncateRunes(s, MaxSummaryRunes)
+}
+
+// limiter 按固定间隔放行请求，多个采集任务共享
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(interval time.Duration) *limiter {
+	return &limiter{interval: interval}
+}
+
+// wait 预约下一个可用时间点并等待，ctx 结束时返回其错误
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,205 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+type memoryCache struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+func (c *memoryCache) GetSummary(_ context.Context, id string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[id]
+	return v, ok
+}
+
+func (c *memoryCache) SaveSummary(_ context.Context, id, _, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[id] = text
+}
+
+const articlePage = `<html><head><title>Go 1.23</title></head><body><nav>Home Blog</nav><article>
+<p>The Go team is happy to announce the release of Go 1.23, which brings range-over-func iterators to the language.</p>
+<p>Iterators let library authors expose sequences that work with for-range loops, and the new iter package defines the standard shapes.</p>
+<p>The toolchain also adds opt-in telemetry, which helps the team understand how the toolchain is used and where it breaks.</p>
+</article></body></html>`
+
+// newServer 模拟原文页面与 Chat Completions 接口，记录送入大模型的用户消息
+func newServer(t *testing.T, prompts *[]string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/post/go1.23":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(articlePage))
+		case "/v1/chat/completions":
+			if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+				t.Errorf("authorization = %q", got)
+			}
+			var req struct {
+				Model    string `json:"model"`
+				Messages []struct {
+					Role    string `json:"role"`
+					Content string `json:"content"`
+				} `json:"messages"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) != 2 {
+				t.Errorf("bad request: %v", err)
+			}
+			mu.Lock()
+			*prompts = append(*prompts, req.Messages[1].Content)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "摘要：“Go 1.23 发布，支持 range-over-func 迭代器。”"}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSummarizeItems(t *testing.T) {
+	var prompts []string
+	srv := newServer(t, &prompts)
+	cache := &memoryCache{m: map[string]string{"cached": "已缓存的摘要"}}
+	s := New(Config{BaseURL: srv.URL + "/v1/", APIKey: "sk-test", Model: "test-model", RatePerMinute: 6000, Client: srv.Client()}, cache)
+
+	items := []processor.ProcessedNews{
+		{ID: "cached", Title: "缓存命中", URL: srv.URL + "/post/cached"},
+		{ID: "article", Title: "Go 1.23 发布", OriginalTitle: "Go 1.23 is released", URL: srv.URL + "/post/go1.23"},
+		{ID: "fallback", Title: "Show HN", URL: srv.URL + "/missing",
+			Description: "A small tool that turns any RSS feed into a daily email digest, written in Go and deployable as a single binary."},
+		{ID: "short", Title: "Ask HN: 标题", URL: srv.URL + "/missing", Description: "Ask HN: 标题"},
+	}
+	s.SummarizeItems(context.Background(), items)
+
+	want := map[string]string{
+		"cached":   "已缓存的摘要",
+		"article":  "Go 1.23 发布，支持 range-over-func 迭代器。",
+		"fallback": "Go 1.23 发布，支持 range-over-func 迭代器。",
+		"short":    "",
+	}
+	for _, it := range items {
+		if it.Summary != want[it.ID] {
+			t.Errorf("%s: summary = %q, want %q", it.ID, it.Summary, want[it.ID])
+		}
+	}
+	if len(prompts) != 2 {
+		t.Fatalf("llm calls = %d, want 2", len(prompts))
+	}
+	if !strings.HasPrefix(prompts[0], "标题：Go 1.23 is released\n\n正文：\nThe Go team is happy") || strings.Contains(prompts[0], "Home Blog") {
+		t.Errorf("article prompt = %q", prompts[0])
+	}
+	if !strings.Contains(prompts[1], "RSS feed into a daily email digest") {
+		t.Errorf("fallback prompt = %q", prompts[1])
+	}
+	if cache.m["article"] == "" || cache.m["fallback"] == "" {
+		t.Errorf("summaries not cached: %v", cache.m)
+	}
+}
+
+func TestSummarizeItemsMaxPerRun(t *testing.T) {
+	var prompts []string
+	srv := newServer(t, &prompts)
+	s := New(Config{BaseURL: srv.URL + "/v1", APIKey: "sk-test", Model: "m", RatePerMinute: 6000, MaxPerRun: 1, Client: srv.Client()}, nil)
+
+	items := []processor.ProcessedNews{
+		{ID: "a", Title: "A", URL: srv.URL + "/post/go1.23"},
+		{ID: "b", Title: "B", URL: srv.URL + "/post/go1.23"},
+	}
+	s.SummarizeItems(context.Background(), items)
+	if items[0].Summary == "" || items[1].Summary != "" {
+		t.Errorf("summaries = %q, %q; want only the first", items[0].Summary, items[1].Summary)
+	}
+}
+
+func TestSummarizeItemsCachesShortInput(t *testing.T) {
+	var prompts []string
+	srv := newServer(t, &prompts)
+	cache := &memoryCache{m: map[string]string{}}
+	s := New(Config{BaseURL: srv.URL + "/v1", APIKey: "sk-test", Model: "m", RatePerMinute: 6000, MaxPerRun: 1, Client: srv.Client()}, cache)
+
+	items := []processor.ProcessedNews{
+		{ID: "short", Title: "Ask HN: 标题", URL: srv.URL + "/missing", Description: "Ask HN: 标题"},
+		{ID: "article", Title: "Go 1.23", URL: srv.URL + "/post/go1.23"},
+	}
+	// 过短的条目同样占用本次的处理上限，并记为无摘要
+	s.SummarizeItems(context.Background(), items)
+	if text, ok := cache.m["short"]; !ok || text != "" {
+		t.Fatalf("short item should be cached as empty, got %q, %v", text, ok)
+	}
+	if items[1].Summary != "" || len(prompts) != 0 {
+		t.Fatalf("MaxPerRun should count the short item: summary = %q, llm calls = %d", items[1].Summary, len(prompts))
+	}
+
+	// 下次采集直接跳过已记为无摘要的条目
+	items[0].Summary, items[1].Summary = "", ""
+	if n := s.SummarizeItems(context.Background(), items); n != 1 {
+		t.Fatalf("filled = %d, want 1", n)
+	}
+	if items[0].Summary != "" || items[1].Summary == "" {
+		t.Errorf("summaries = %q, %q; want only the article", items[0].Summary, items[1].Summary)
+	}
+}
+
+func TestNewDisabled(t *testing.T) {
+	if New(Config{BaseURL: "https://api.example.com/v1"}, nil) != nil {
+		t.Error("New without model should return nil")
+	}
+	if New(Config{Model: "m"}, nil) != nil {
+		t.Error("New without base url should return nil")
+	}
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := newLimiter(20 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("3 waits took %v, want >= 40ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = newLimiter(time.Hour)
+	_ = l.wait(ctx)
+	if err := l.wait(ctx); err == nil {
+		t.Error("wait on canceled ctx should fail")
+	}
+}
+
+func TestCleanSummary(t *testing.T) {
+	cases := map[string]string{
+		"  摘要：这是一段摘要。 ":       "这是一段摘要。",
+		"\"Quoted summary.\"": "Quoted summary.",
+		"第一句。\n\n第二句。":        "第一句。 第二句。",
+	}
+	for in, want := range cases {
+		if got := cleanSummary(in); got != want {
+			t.Errorf("cleanSummary(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := cleanSummary(strings.Repeat("长", MaxSummaryRunes+10)); len([]rune(got)) != MaxSummaryRunes+1 {
+		t.Errorf("long summary not truncated: %d runes", len([]rune(got)))
+	}
+}
@@ -0,0 +1,139 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LJTian/TrendingHub/internal/collector"
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+const (
+	maxResponseBytes = 1 << 20
+	// maxDescriptionRunes 送去分类的介绍长度上限，控制 token 消耗
+	maxDescriptionRunes = 120
+)
+
+// classifyBatch 请求大模型为一批条目分类，结果写入条目并缓存；请求或解析失败时本批条目不缓存，下次采集重试
+func (t *Tagger) classifyBatch(ctx context.Context, items []processor.ProcessedNews, idx []int) {
+	var b strings.Builder
+	for n, i := range idx {
+		it := items[i]
+		fmt.Fprintf(&b, "%d. %s", n+1, collector.FirstNonEmpty(it.OriginalTitle, it.Title))
+		if desc := collector.FirstNonEmpty(it.OriginalDescription, it.Description); desc != "" && desc != it.Title {
+			fmt.Fprintf(&b, "（%s）", processor.TruncateRunes(desc, maxDescriptionRunes))
+		}
+		b.WriteByte('\n')
+	}
+	content, err := t.complete(ctx, b.String())
+	if err != nil {
+		log.Printf("tagger: classify %d items: %v", len(idx), err)
+		return
+	}
+	result, err := parseClassification(content)
+	if err != nil {
+		log.Printf("tagger: classify %d items: %v", len(idx), err)
+		return
+	}
+	for n, i := range idx {
+		tags, ok := result[strconv.Itoa(n+1)]
+		if !ok {
+			continue
+		}
+		tags = t.knownTags(tags)
+		items[i].Tags = union(items[i].Tags, tags)
+		if t.cache != nil {
+			t.cache.SaveTagClassification(ctx, items[i].ID, t.cfg.Model, tags)
+		}
+	}
+}
+
+// knownTags 只保留词典中存在的标签，最多 maxTagsPerItem 个
+func (t *Tagger) knownTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if _, ok := t.matcher.keywords[tag]; ok && len(out) < maxTagsPerItem {
+			out = append(out, tag)
+		}
+	}
+	return union(out, nil)
+}
+
+func (t *Tagger) systemPrompt() string {
+	return "你是资讯分类助手。可用标签：" + strings.Join(t.matcher.tags, ", ") + "。" +
+		"用户会给出若干条编号的资讯标题（括号内为介绍），请为每条选择 0~3 个最贴切的标签，只能使用可用标签，都不贴切时给空数组。" +
+		`只输出 JSON 对象，key 为编号，value 为标签数组，例如 {"1":["ai"],"2":[]}。`
+}
+
+// complete 请求 Chat Completions 接口，返回模型输出的原文
+func (t *Tagger) complete(ctx context.Context, input string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]any{
+		"model":       t.cfg.Model,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "system", "content": t.systemPrompt()},
+			{"role": "user", "content": input},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.cfg.APIKey)
+	}
+	resp, err := t.cfg.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("chat completions: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("chat completions: decode: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("chat completions: no choices")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+// parseClassification 解析模型输出的 JSON 对象，容忍 ```json 代码块与前后的说明文字
+func parseClassification(content string) (map[string][]string, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no json object in response")
+	}
+	var out map[string][]string
+	if err := json.Unmarshal([]byte(content[start:end+1]), &out); err != nil {
+		return nil, fmt.Errorf("decode classification: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,160 @@
+package tagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/LJTian/TrendingHub/internal/filter"
+)
+
+// TagPattern 标签名称的格式：小写字母、数字、下划线或连字符
+var TagPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Dictionary 标签词典：标签 → 关键词 / 同义词（中英文均可），匹配时忽略大小写与全角 / 半角差异
+type Dictionary map[string][]string
+
+// DefaultDictionary 内置词典。纯字母数字的关键词按整词匹配（ai 不会命中 said），含中文的关键词按子串匹配
+func DefaultDictionary() Dictionary {
+	return Dictionary{
+		"ai": {"人工智能", "大模型", "大语言模型", "机器学习", "深度学习", "神经网络", "智能体", "生成式", "算力",
+			"ai", "aigc", "agi", "llm", "llms", "gpt", "chatgpt", "openai", "anthropic", "claude", "gemini", "deepseek", "copilot",
+			"machine learning", "deep learning", "neural network", "通义千问", "文心一言", "豆包", "kimi"},
+		"finance": {"金融", "股市", "股票", "a股", "港股", "美股", "基金", "债券", "央行", "利率", "降息", "加息", "通胀", "汇率",
+			"财报", "证监会", "美联储", "finance", "stock", "stocks", "bond", "bonds", "interest rate", "inflation",
+			"ipo", "nasdaq", "wall street", "earnings", "federal reserve"},
+		"crypto": {"比特币", "以太坊", "加密货币", "区块链", "稳定币", "bitcoin", "btc", "ethereum", "eth", "crypto",
+			"cryptocurrency", "blockchain", "web3", "stablecoin", "defi", "nft"},
+		"sports": {"体育", "足球", "篮球", "排球", "网球", "世界杯", "奥运", "亚运", "中超", "英超", "西甲", "欧冠", "国足", "cba", "nba",
+			"football", "soccer", "basketball", "tennis", "olympics", "world cup", "premier league", "f1", "formula 1"},
+		"security": {"网络安全", "信息安全", "漏洞", "黑客", "勒索", "数据泄露", "恶意软件", "木马", "钓鱼",
+			"security", "vulnerability", "vulnerabilities", "cve", "exploit", "malware", "ransomware", "hacker", "hackers",
+			"breach", "phishing", "zero-day", "0day", "backdoor"},
+		"programming": {"编程", "程序员", "开源", "编程语言", "编译器", "数据库", "golang", "rust", "python", "javascript",
+			"typescript", "java", "kotlin", "swift", "c++", "kubernetes", "docker", "linux", "postgres", "compiler",
+			"open source", "open-source", "programming", "developer", "developers", "api", "sdk"},
+		"science": {"科学", "科研", "物理", "化学", "天文", "航天", "火箭", "卫星", "量子", "基因", "论文",
+			"science", "physics", "chemistry", "astronomy", "nasa", "spacex", "rocket", "quantum", "genome"},
+		"health": {"健康", "医疗", "医院", "疫苗", "病毒", "疾病", "癌症", "医保", "health", "medical", "medicine",
+			"vaccine", "cancer", "covid", "fda", "disease"},
+		"entertainment": {"娱乐", "明星", "电影", "电视剧", "综艺", "演唱会", "票房", "歌手", "演员",
+			"movie", "movies", "film", "netflix", "box office", "celebrity", "music", "album"},
+		"games": {"游戏", "电竞", "手游", "主机游戏", "原神", "黑神话", "steam", "nintendo", "switch 2", "playstation", "ps5",
+			"xbox", "esports", "gaming", "video game", "video games"},
+		"auto": {"汽车", "新能源车", "电动车", "自动驾驶", "智驾", "特斯拉", "比亚迪", "蔚来", "小鹏", "理想汽车",
+			"tesla", "byd", "ev", "evs", "electric vehicle", "self-driving", "autonomous driving", "waymo"},
+	}
+}
+
+// LoadDictionary 在内置词典的基础上合并 JSON 文件（{"标签": ["关键词", ...]}）：文件中的标签整体替换内置的同名标签，
+// 关键词为空数组表示删除该标签；path 为空时返回内置词典
+func LoadDictionary(path string) (Dictionary, error) {
+	dict := DefaultDictionary()
+	if path == "" {
+		return dict, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var custom Dictionary
+	if err := json.Unmarshal(b, &custom); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for tag, words := range custom {
+		if !TagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q in %s, expected [a-z0-9_-]{1,32}", tag, path)
+		}
+		if len(words) == 0 {
+			delete(dict, tag)
+			continue
+		}
+		dict[tag] = words
+	}
+	return dict, nil
+}
+
+// keyword 归一化后的关键词；首 / 尾字符为 ASCII 字母或数字时，该侧要求单词边界
+type keyword struct {
+	text        string
+	left, right bool
+}
+
+// matcher 编译后的词典
+type matcher struct {
+	tags     []string
+	keywords map[string][]keyword
+}
+
+func newMatcher(dict Dictionary) *matcher {
+	m := &matcher{keywords: make(map[string][]keyword, len(dict))}
+	for tag, words := range dict {
+		var kws []keyword
+		for _, w := range words {
+			w = strings.TrimSpace(filter.Normalize(w))
+			if w == "" {
+				continue
+			}
+			kws = append(kws, keyword{text: w, left: isWordByte(w[0]), right: isWordByte(w[len(w)-1])})
+		}
+		if len(kws) == 0 {
+			continue
+		}
+		m.tags = append(m.tags, tag)
+		m.keywords[tag] = kws
+	}
+	sort.Strings(m.tags)
+	return m
+}
+
+// match 返回命中的标签（按名称排序）
+func (m *matcher) match(texts ...string) []string {
+	normalized := make([]string, 0, len(texts))
+	for _, t := range texts {
+		if t != "" {
+			normalized = append(normalized, filter.Normalize(t))
+		}
+	}
+	tags := make([]string, 0)
+	for _, tag := range m.tags {
+		if m.matchTag(tag, normalized) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (m *matcher) matchTag(tag string, texts []string) bool {
+	for _, kw := range m.keywords[tag] {
+		for _, t := range texts {
+			if containsKeyword(t, kw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsKeyword 查找关键词的每次出现，检查需要单词边界的一侧不与字母数字相连
+func containsKeyword(s string, kw keyword) bool {
+	for start := 0; start <= len(s)-len(kw.text); {
+		i := strings.Index(s[start:], kw.text)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(kw.text)
+		if (!kw.left || i == 0 || !isWordByte(s[i-1])) && (!kw.right || end == len(s) || !isWordByte(s[end])) {
+			return true
+		}
+		start = i + 1
+	}
+	return false
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
@@ -0,0 +1,145 @@
+// Package tagger 为条目打主题标签（如 ai、finance、sports、security）：先按词典匹配标题与介绍，
+// 可选地对词典未命中的条目调用 OpenAI 兼容的大模型分类，标签写入 news_tags 表
+package tagger
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+const (
+	defaultMaxPerRun = 40
+	// batchSize 每次请求大模型分类的条目数
+	batchSize = 20
+	// maxTagsPerItem 大模型为单个条目最多给出的标签数
+	maxTagsPerItem = 3
+	requestTimeout = 60 * time.Second
+)
+
+// DefaultSkipSources 默认不打标签的数据源：金融快照每分钟一条，会淹没 finance 标签的统计
+var DefaultSkipSources = []string{"gold", "ashare"}
+
+// Config 打标签配置
+type Config struct {
+	// Dictionary 标签词典，为 nil 时使用 DefaultDictionary
+	Dictionary Dictionary
+	// SkipSources 不打标签的数据源，为 nil 时使用 DefaultSkipSources
+	SkipSources []string
+	// BaseURL / Model 分类使用的 OpenAI 兼容接口（请求 <base>/chat/completions）与模型，任一为空时只按词典打标签
+	BaseURL string
+	APIKey  string
+	Model   string
+	// MaxPerRun 单次采集最多送去分类的条目数，其余条目留待下次采集，<=0 时为 40
+	MaxPerRun int
+	// Client 可选，注入自定义 HTTP 客户端
+	Client *http.Client
+}
+
+// Cache 大模型分类结果的缓存，key 为条目 ID；分类结果为空也会缓存，同一条目只请求一次
+type Cache interface {
+	GetTagClassifications(ctx context.Context, ids []string) map[string][]string
+	SaveTagClassification(ctx context.Context, id, model string, tags []string)
+}
+
+// Tagger 打标签阶段
+type Tagger struct {
+	cfg     Config
+	cache   Cache
+	matcher *matcher
+	skip    map[string]bool
+}
+
+// New 创建打标签服务
+func New(cfg Config, cache Cache) *Tagger {
+	if cfg.Dictionary == nil {
+		cfg.Dictionary = DefaultDictionary()
+	}
+	if cfg.SkipSources == nil {
+		cfg.SkipSources = DefaultSkipSources
+	}
+	cfg.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = defaultMaxPerRun
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{}
+	}
+	skip := make(map[string]bool, len(cfg.SkipSources))
+	for _, s := range cfg.SkipSources {
+		skip[s] = true
+	}
+	return &Tagger{cfg: cfg, cache: cache, matcher: newMatcher(cfg.Dictionary), skip: skip}
+}
+
+// Tags 返回词典中的全部标签（按名称排序）
+func (t *Tagger) Tags() []string {
+	return slices.Clone(t.matcher.tags)
+}
+
+// Match 按词典返回文本命中的标签
+func (t *Tagger) Match(texts ...string) []string {
+	return t.matcher.match(texts...)
+}
+
+func (t *Tagger) classifierEnabled() bool {
+	return t.cfg.BaseURL != "" && t.cfg.Model != ""
+}
+
+// TagItems 为条目填写 Tags：词典匹配标题、原标题、介绍与原介绍；开启分类时，合并已缓存的分类结果，
+// 词典未命中且未分类过的条目送去分类（单次最多 MaxPerRun 条，失败只记日志）。
+// 跳过的数据源 Tags 保持为 nil，入库时不改动已有标签。返回至少有一个标签的条目数
+func (t *Tagger) TagItems(ctx context.Context, items []processor.ProcessedNews) int {
+	var cached map[string][]string
+	if t.classifierEnabled() && t.cache != nil {
+		ids := make([]string, 0, len(items))
+		for _, it := range items {
+			if !t.skip[it.Source] {
+				ids = append(ids, it.ID)
+			}
+		}
+		if len(ids) > 0 {
+			cached = t.cache.GetTagClassifications(ctx, ids)
+		}
+	}
+
+	var pending []int
+	for i := range items {
+		it := &items[i]
+		if t.skip[it.Source] {
+			continue
+		}
+		it.Tags = t.matcher.match(it.Title, it.OriginalTitle, it.Description, it.OriginalDescription)
+		if !t.classifierEnabled() {
+			continue
+		}
+		if tags, ok := cached[it.ID]; ok {
+			it.Tags = union(it.Tags, tags)
+		} else if len(it.Tags) == 0 && len(pending) < t.cfg.MaxPerRun {
+			pending = append(pending, i)
+		}
+	}
+
+	for start := 0; start < len(pending) && ctx.Err() == nil; start += batchSize {
+		t.classifyBatch(ctx, items, pending[start:min(start+batchSize, len(pending))])
+	}
+
+	tagged := 0
+	for _, it := range items {
+		if len(it.Tags) > 0 {
+			tagged++
+		}
+	}
+	return tagged
+}
+
+// union 合并两组标签，结果按名称排序且不重复
+func union(a, b []string) []string {
+	out := append(slices.Clone(a), b...)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,156 @@
+package tagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/LJTian/TrendingHub/internal/processor"
+)
+
+func TestMatch(t *testing.T) {
+	tg := New(Config{}, nil)
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"OpenAI 发布新一代大模型", []string{"ai"}},
+		{"ＡＩ 芯片出口管制", []string{"ai"}},
+		{"He said the rain would stop", []string{}},
+		{"Show HN: an LLM-powered debugger for Rust", []string{"ai", "programming"}},
+		{"国足 1:0 战胜对手", []string{"sports"}},
+		{"沪深A股三大指数集体收涨", []string{"finance"}},
+		{"Critical CVE in OpenSSH allows remote exploit", []string{"security"}},
+		{"Evening news roundup", []string{}},
+		{"Tesla recalls 10,000 EVs", []string{"auto"}},
+		{"C++26 draft approved", []string{"programming"}},
+	}
+	for _, c := range cases {
+		if got := tg.Match(c.text); !slices.Equal(got, c.want) {
+			t.Errorf("Match(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.json")
+	if err := os.WriteFile(path, []byte(`{"space": ["航天", "SpaceX"], "games": [], "ai": ["人工智能"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dict, err := LoadDictionary(path)
+	if err != nil {
+		t.Fatalf("LoadDictionary error: %v", err)
+	}
+	if _, ok := dict["games"]; ok {
+		t.Error("empty keyword list should remove the tag")
+	}
+	if !slices.Equal(dict["ai"], []string{"人工智能"}) || len(dict["space"]) != 2 || len(dict["finance"]) == 0 {
+		t.Errorf("custom tags not merged: ai=%v space=%v", dict["ai"], dict["space"])
+	}
+
+	if err := os.WriteFile(path, []byte(`{"Bad Tag": ["x"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDictionary(path); err == nil {
+		t.Error("invalid tag name should fail")
+	}
+}
+
+type memoryCache struct {
+	mu sync.Mutex
+	m  map[string][]string
+}
+
+func (c *memoryCache) GetTagClassifications(_ context.Context, ids []string) map[string][]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string][]string)
+	for _, id := range ids {
+		if tags, ok := c.m[id]; ok {
+			out[id] = tags
+		}
+	}
+	return out
+}
+
+func (c *memoryCache) SaveTagClassification(_ context.Context, id, _ string, tags []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[id] = tags
+}
+
+// newServer 模拟 Chat Completions 接口：第一条分类为 science 与一个未知标签，其余为空，记录送入大模型的用户消息
+func newServer(t *testing.T, prompts *[]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) != 2 {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+		if !strings.Contains(req.Messages[0].Content, "ai, auto,") {
+			t.Errorf("system prompt should list tags: %q", req.Messages[0].Content)
+		}
+		*prompts = append(*prompts, req.Messages[1].Content)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": "```json\n{\"1\": [\"science\", \"weather\"], \"2\": []}\n```"}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTagItems(t *testing.T) {
+	var prompts []string
+	srv := newServer(t, &prompts)
+	cache := &memoryCache{m: map[string][]string{"cached": {"health"}}}
+	tg := New(Config{BaseURL: srv.URL + "/", Model: "test"}, cache)
+
+	items := []processor.ProcessedNews{
+		{ID: "dict", Source: "hackernews", Title: "DeepSeek releases a new model"},
+		{ID: "cached", Source: "weibo", Title: "某地出现罕见天象"},
+		{ID: "new1", Source: "weibo", Title: "观测到引力波新信号", OriginalTitle: "Scientists observe new gravitational wave signal"},
+		{ID: "new2", Source: "weibo", Title: "今日热议"},
+		{ID: "gold", Source: "gold", Title: "黄金价格"},
+	}
+	if n := tg.TagItems(context.Background(), items); n != 3 {
+		t.Errorf("tagged = %d, want 3", n)
+	}
+	if !slices.Equal(items[0].Tags, []string{"ai"}) || !slices.Equal(items[1].Tags, []string{"health"}) ||
+		!slices.Equal(items[2].Tags, []string{"science"}) || items[3].Tags == nil || len(items[3].Tags) != 0 || items[4].Tags != nil {
+		t.Errorf("tags = %v %v %v %v %v", items[0].Tags, items[1].Tags, items[2].Tags, items[3].Tags, items[4].Tags)
+	}
+	// 只有词典未命中且未缓存的条目送去分类，一次请求
+	if len(prompts) != 1 || !strings.Contains(prompts[0], "1. Scientists observe") || !strings.Contains(prompts[0], "2. 今日热议") || strings.Contains(prompts[0], "DeepSeek") {
+		t.Fatalf("prompts = %q", prompts)
+	}
+	// 分类结果（含空结果）写入缓存，下次不再请求
+	if tags, ok := cache.m["new2"]; !ok || len(tags) != 0 {
+		t.Errorf("empty classification should be cached: %v %v", tags, ok)
+	}
+	items[2].Tags, items[3].Tags = nil, nil
+	tg.TagItems(context.Background(), items[2:4])
+	if len(prompts) != 1 || !slices.Equal(items[2].Tags, []string{"science"}) {
+		t.Errorf("cached classification should be reused: prompts=%d tags=%v", len(prompts), items[2].Tags)
+	}
+}
+
+func TestParseClassification(t *testing.T) {
+	if got, err := parseClassification(`结果如下：{"1": ["ai"]}`); err != nil || !slices.Equal(got["1"], []string{"ai"}) {
+		t.Errorf("parse = %v, %v", got, err)
+	}
+	if _, err := parseClassification("没有合适的标签"); err == nil {
+		t.Error("missing json should fail")
+	}
+}
@@ -1,6 +1,6 @@
 import React, { useEffect, useState, useMemo } from "react";
 import { fetchNews, fetchNewsDates } from "./api";
-import type { NewsItem } from "./types";
+import type { LinkPreview, NewsItem } from "./types";
 import { GoldChart } from "./GoldChart";
 import { AshareBlock } from "./AshareBlock";
 import { AshareStocksManager } from "./AshareStocksManager";
@@ -9,12 +9,80 @@ import { WeatherCard } from "./WeatherCard";
 
 const CHANNELS = [
   { code: "github", label: "GitHub Trending", sources: ["github"] },
+  { code: "github_developers", label: "GitHub 开发者", sources: ["github_developers"] },
   { code: "baidu", label: "百度热搜", sources: ["baidu"] },
+  { code: "weibo", label: "微博热搜", sources: ["weibo"] },
+  { code: "zhihu", label: "知乎热榜", sources: ["zhihu"] },
+  { code: "bilibili", label: "B 站热门", sources: ["bilibili"] },
+  { code: "douyin", label: "抖音热榜", sources: ["douyin"] },
   { code: "hackernews", label: "Hacker News", sources: ["hackernews"] },
+  { code: "v2ex", label: "V2EX", sources: ["v2ex"] },
+  { code: "lobsters", label: "Lobsters", sources: ["lobsters"] },
+  { code: "reddit", label: "Reddit", sources: ["reddit"] },
+  { code: "producthunt", label: "Product Hunt", sources: ["producthunt"] },
+  { code: "arxiv", label: "arXiv", sources: ["arxiv"] },
+  { code: "papers", label: "热门论文", sources: ["papers"] },
+  { code: "x", label: "X 趋势", sources: ["x"] },
   { code: "gold", label: "金融", sources: ["gold", "ashare"] }
 ];
 
 const CHANNEL_CODES = CHANNELS.map((ch) => ch.code);
+
+/** X 趋势可选地区，与后端 x 渠道 config.regions 默认值一致 */
+const X_REGIONS = [
+  { code: "", label: "全部" },
+  { code: "worldwide", label: "全球" },
+  { code: "china", label: "中国" },
+  { code: "japan", label: "日本" },
+  { code: "united-states", label: "美国" }
+];
+
+/** GitHub Trending 语言与时间范围筛选，与后端 github 渠道 config 默认值一致 */
+const GITHUB_LANGUAGES = [
+  { code: "", label: "全部语言" },
+  { code: "go", label: "Go" },
+  { code: "rust", label: "Rust" },
+  { code: "typescript", label: "TypeScript" }
+];
+const GITHUB_SINCE = [
+  { code: "", label: "全部" },
+  { code: "daily", label: "今日" },
+  { code: "weekly", label: "本周" }
+];
+/** Hacker News 榜单筛选，与后端 hackernews 渠道 config.lists 默认值一致 */
+const HN_LISTS = [
+  { code: "", label: "全部" },
+  { code: "top", label: "Top" },
+  { code: "best", label: "Best" },
+  { code: "ask", label: "Ask HN" },
+  { code: "show", label: "Show HN" },
+  { code: "jobs", label: "Jobs" }
+];
+/** arXiv / 热门论文分类筛选，与后端 arxiv 渠道 config.categories 默认值一致 */
+const PAPER_CATEGORIES = [
+  { code: "", label: "全部" },
+  { code: "cs.AI", label: "cs.AI" },
+  { code: "cs.CL", label: "cs.CL" },
+  { code: "cs.DC", label: "cs.DC" }
+];
+const PAPER_CHANNELS = ["arxiv", "papers"];
+/** 带真实热度值（extraData.heat）的中文热榜 */
+const HEAT_SOURCES = ["weibo", "zhihu", "bilibili", "douyin"];
+
+/** 带评论数（extraData.comments）的社区类渠道 */
+const COMMENT_SOURCES = ["hackernews", "v2ex", "lobsters", "reddit", "producthunt"];
+/** 社区渠道的得票数字段：Lobsters / Reddit 为 score，Product Hunt 为 votes */
+function communityVotes(item: NewsItem): number | undefined {
+  const v = item.source === "producthunt" ? item.extraData?.votes : item.extraData?.score;
+  return typeof v === "number" ? v : undefined;
+}
+
+/** 热度值按万 / 亿缩写 */
+function formatHeat(n: number): string {
+  if (n >= 1e8) return `${(n / 1e8).toFixed(1)}亿`;
+  if (n >= 1e4) return `${(n / 1e4).toFixed(1)}万`;
+  return Math.round(n).toLocaleString();
+}
 const SEARCH_DATE_REGEX = /^\d{4}-\d{2}-\d{2}$/;
 
 const HOME_PREVIEW_COUNT = 5;
@@ -55,6 +123,12 @@ function truncateText(text: string | undefined, limit: number): string {
   return chars.slice(0, limit).join("") + "…";
 }
 
+/** 取条目的链接预览（extraData.preview），没有时返回 undefined */
+function linkPreview(item: NewsItem): LinkPreview | undefined {
+  const preview = item.extraData?.preview;
+  return preview && typeof preview === "object" ? (preview as LinkPreview) : undefined;
+}
+
 export const App: React.FC = () => {
   const today = useMemo(() => todayEast8(), []);
   const initialSearch = useMemo(() => getInitialSearchState(today), [today]);
@@ -64,6 +138,11 @@ export const App: React.FC = () => {
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState<string | null>(null);
   const [items, setItems] = useState<NewsItem[]>([]);
+  const [region, setRegion] = useState<string>("");
+  const [githubLanguage, setGithubLanguage] = useState<string>("");
+  const [githubSince, setGithubSince] = useState<string>("");
+  const [hnList, setHnList] = useState<string>("");
+  const [paperCategory, setPaperCategory] = useState<string>("");
 
   useEffect(() => {
     if (typeof window === "undefined") return;
@@ -109,7 +188,12 @@ export const App: React.FC = () => {
           channel,
           sort: "hot",
           limit: isGold ? 500 : 30,
-          date: date || undefined
+          date: date || undefined,
+          region: channel === "x" ? region || undefined : undefined,
+          language: channel === "github" ? githubLanguage || undefined : undefined,
+          since: channel === "github" ? githubSince || undefined : undefined,
+          list: channel === "hackernews" ? hnList || undefined : undefined,
+          category: PAPER_CHANNELS.includes(channel) ? paperCategory || undefined : undefined
         });
         setItems(data);
       }
@@ -123,7 +207,7 @@ export const App: React.FC = () => {
   useEffect(() => {
     void load();
     // eslint-disable-next-line react-hooks/exhaustive-deps
-  }, [channel, date]);
+  }, [channel, date, region, githubLanguage, githubSince, hnList, paperCategory]);
 
   useEffect(() => {
     fetchNewsDates({ channel: channel || undefined, limit: 31 })
@@ -362,6 +446,73 @@ export const App: React.FC = () => {
               <h2 className="section-title">
                 {CHANNELS.find((c) => c.code === channel)?.label ?? "今日热点"}
               </h2>
+              {channel === "x" && (
+                <div className="filter-bar">
+                  {X_REGIONS.map((r) => (
+                    <button
+                      key={r.code}
+                      type="button"
+                      className={region === r.code ? "active" : ""}
+                      onClick={() => setRegion(r.code)}
+                    >
+                      {r.label}
+                    </button>
+                  ))}
+                </div>
+              )}
+              {channel === "github" && (
+                <div className="filter-bar">
+                  {GITHUB_LANGUAGES.map((l) => (
+                    <button
+                      key={l.code}
+                      type="button"
+                      className={githubLanguage === l.code ? "active" : ""}
+                      onClick={() => setGithubLanguage(l.code)}
+                    >
+                      {l.label}
+                    </button>
+                  ))}
+                  <span className="filter-bar-sep" />
+                  {GITHUB_SINCE.map((r) => (
+                    <button
+                      key={r.code}
+                      type="button"
+                      className={githubSince === r.code ? "active" : ""}
+                      onClick={() => setGithubSince(r.code)}
+                    >
+                      {r.label}
+                    </button>
+                  ))}
+                </div>
+              )}
+              {channel === "hackernews" && (
+                <div className="filter-bar">
+                  {HN_LISTS.map((l) => (
+                    <button
+                      key={l.code}
+                      type="button"
+                      className={hnList === l.code ? "active" : ""}
+                      onClick={() => setHnList(l.code)}
+                    >
+                      {l.label}
+                    </button>
+                  ))}
+                </div>
+              )}
+              {PAPER_CHANNELS.includes(channel) && (
+                <div className="filter-bar">
+                  {PAPER_CATEGORIES.map((cat) => (
+                    <button
+                      key={cat.code}
+                      type="button"
+                      className={paperCategory === cat.code ? "active" : ""}
+                      onClick={() => setPaperCategory(cat.code)}
+                    >
+                      {cat.label}
+                    </button>
+                  ))}
+                </div>
+              )}
               <ul className="list">
                 {items.map((item, index) => (
                   <li key={item.id} className="card">
@@ -376,6 +527,7 @@ export const App: React.FC = () => {
                         {item.title}
                       </a>
                     </div>
+                    {item.originalTitle && <div className="card-original">{item.originalTitle}</div>}
                     <div className="card-meta">
                       <span className="badge">{item.source}</span>
                       <span className="dot" />
@@ -392,13 +544,40 @@ export const App: React.FC = () => {
                           <span className="card-stars" title="Stars">
                             ★ {Math.round(item.hotScore).toLocaleString()}
                           </span>
+                          {typeof item.extraData?.starsToday === "number" && (
+                            <span className="card-stars" title="今日新增 Stars">
+                              +{Number(item.extraData.starsToday).toLocaleString()} today
+                            </span>
+                          )}
+                          {typeof item.extraData?.language === "string" && (
+                            <>
+                              <span className="dot" />
+                              <span>{String(item.extraData.language)}</span>
+                            </>
+                          )}
+                        </>
+                      )}
+                      {HEAT_SOURCES.includes(item.source) && typeof item.extraData?.heat === "number" && (
+                        <>
+                          <span className="dot" />
+                          <span className="card-stars" title={item.source === "bilibili" ? "播放量" : "热度"}>
+                            🔥 {formatHeat(Number(item.extraData.heat))}
+                          </span>
+                        </>
+                      )}
+                      {communityVotes(item) !== undefined && (
+                        <>
+                          <span className="dot" />
+                          <span className="card-stars" title="Votes">
+                            ▲ {Number(communityVotes(item)).toLocaleString()}
+                          </span>
                         </>
                       )}
-                      {item.source === "hackernews" && item.extraData && (
+                      {COMMENT_SOURCES.includes(item.source) && typeof item.extraData?.comments === "number" && (
                         <>
                           <span className="dot" />
                           <span className="card-comments" title="Comments">
-                            💬 {Number(item.extraData.comments ?? 0)}
+                            💬 {Number(item.extraData.comments)}
                           </span>
                         </>
                       )}
@@ -406,15 +585,33 @@ export const App: React.FC = () => {
                     <div className="card-tooltip" role="tooltip">
                       <div className="card-tooltip-title">详细信息</div>
                       <div className="card-tooltip-body">
+                        {linkPreview(item)?.image && (
+                          <img
+                            className="card-tooltip-image"
+                            src={linkPreview(item)?.image}
+                            alt={linkPreview(item)?.title ?? ""}
+                            loading="lazy"
+                          />
+                        )}
+                        {item.summary && (
+                          <div className="card-tooltip-main card-summary">
+                            摘要：{item.summary}
+                          </div>
+                        )}
                         <div className="card-tooltip-main">
                           {truncateText(
                             item.description || item.title || "暂无介绍",
                             600
                           )}
                         </div>
+                        {item.originalDescription && (
+                          <div className="card-tooltip-main card-original">
+                            原文：{truncateText(item.originalDescription, 600)}
+                          </div>
+                        )}
                         <div className="card-tooltip-extra">
                           <p>
-                            来源：{item.source} · 热度：
+                            来源：{linkPreview(item)?.siteName ? `${item.source}（${linkPreview(item)?.siteName}）` : item.source} · 热度：
                             {Math.round(item.hotScore)} · 发布时间：
                             {new Date(item.publishedAt).toLocaleString(
                               "zh-CN",
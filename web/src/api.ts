@@ -7,12 +7,24 @@ export async function fetchNews(params: {
   sort?: "latest" | "hot";
   limit?: number;
   date?: string; // 可选，YYYY-MM-DD，按日期展示
+  region?: string; // 可选，X 趋势地区，如 japan
+  language?: string; // 可选，GitHub Trending 语言，如 go
+  since?: string; // 可选，GitHub Trending 时间范围 daily / weekly / monthly
+  list?: string; // 可选，Hacker News 榜单 top / best / new / ask / show / jobs
+  category?: string; // 可选，arXiv 分类，如 cs.AI
+  lang?: string; // 可选，标题与介绍的语言：original 为原文，其它为语言代码，默认中文
 }): Promise<NewsItem[]> {
   const search = new URLSearchParams();
   if (params.channel) search.set("channel", params.channel);
   if (params.sort) search.set("sort", params.sort);
   if (params.limit) search.set("limit", String(params.limit));
   if (params.date) search.set("date", params.date);
+  if (params.region) search.set("region", params.region);
+  if (params.language) search.set("language", params.language);
+  if (params.since) search.set("since", params.since);
+  if (params.list) search.set("list", params.list);
+  if (params.category) search.set("category", params.category);
+  if (params.lang) search.set("lang", params.lang);
 
   const res = await fetch(`${BASE_URL}/api/v1/news?${search.toString()}`);
   const contentType = res.headers.get("content-type") ?? "";
@@ -4,18 +4,45 @@ export interface NewsItem {
   url: string;
   source: string;
   description?: string;
+  /** 翻译前的原文，仅在 title / description 为译文时存在 */
+  originalTitle?: string;
+  originalDescription?: string;
+  /** 其它语言的译文：语言代码 → { title, description } */
+  translations?: Record<string, { title?: string; description?: string }>;
+  /** 大模型生成的中文摘要，仅开启 summarize 的渠道存在 */
+  summary?: string;
+  /** 跨数据源聚合得到的事件 ID，见 /api/v1/stories */
+  storyId?: string;
+  /** 主题标签（如 ai、finance），可用 /api/v1/news?tag= 过滤 */
+  tags?: string[];
   publishedAt: string;
   publishedDate?: string;
   hotScore: number;
   extraData?: Record<string, unknown>;
 }
 
+/** 链接预览：开启 enrich 的渠道在 extraData.preview 中提供 */
+export interface LinkPreview {
+  title?: string;
+  description?: string;
+  image?: string;
+  siteName?: string;
+  canonicalUrl?: string;
+  text?: string;
+}
+
 export interface ApiResponse<T> {
   code: string;
   message: string;
   data: T;
 }
 
+/** /api/v1/news 返回的标签分面 */
+export interface TagCount {
+  tag: string;
+  count: number;
+}
+
 export interface WttrCondition {
   temp_C: string;
   FeelsLikeC: string;